@@ -4,7 +4,7 @@ resource "porkbun_dns_record" "www" {
   name    = "www"
   type    = "A"
   content = "192.0.2.1"
-  ttl     = "600"
+  ttl     = 600
 }
 
 // Test MX record type
@@ -13,8 +13,8 @@ resource "porkbun_dns_record" "mx_mail" {
   name    = "mail"
   type    = "MX"
   content = var.mx_server
-  ttl     = "3600"
-  prio    = "10"
+  ttl     = 3600
+  prio    = 10
 }
 
 // Test MX record type on root domain
@@ -23,6 +23,6 @@ resource "porkbun_dns_record" "root_mail" {
   name    = ""
   type    = "MX"
   content = var.mx_server
-  ttl     = "3600"
-  prio    = "10"
+  ttl     = 3600
+  prio    = 10
 }
@@ -0,0 +1,112 @@
+package porkbun
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// cache holds the last known API view per key (usually a domain).
+//
+// Every write to a key bumps its generation. A fill that was started before
+// the write is discarded, so a slow read that raced with a mutation can not
+// overwrite the cached view with data the server no longer has.
+type cache[T any] struct {
+	mu          sync.Mutex
+	entries     map[string]cacheEntry[T]
+	generations map[string]uint64
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	fetched time.Time
+}
+
+func newCache[T any]() *cache[T] {
+	return &cache[T]{
+		entries:     make(map[string]cacheEntry[T]),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the cached value for key. Entries older than ttl are treated as
+// missing; a ttl of zero or less never expires entries.
+func (c *cache[T]) get(key string, ttl time.Duration) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || (ttl > 0 && time.Since(entry.fetched) > ttl) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// generation returns the current generation of key. It has to be read before
+// the request whose result is passed to fill is sent.
+func (c *cache[T]) generation(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[key]
+}
+
+// fill stores value unless key was written since generation was read.
+func (c *cache[T]) fill(key string, generation uint64, value T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[key] != generation {
+		return false
+	}
+	c.entries[key] = cacheEntry[T]{value: value, fetched: time.Now()}
+	return true
+}
+
+// update applies a successful mutation to the cached view of key. fn must not
+// modify its argument in place, values handed out by get are shared. Nothing
+// is cached when key has no entry yet.
+func (c *cache[T]) update(key string, fn func(T) T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[key]++
+	if entry, ok := c.entries[key]; ok {
+		entry.value = fn(entry.value)
+		c.entries[key] = entry
+	}
+}
+
+// invalidate drops key, e.g. after a mutation with an unknown outcome.
+func (c *cache[T]) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[key]++
+	delete(c.entries, key)
+}
+
+// cachedRecord mirrors how dns/retrieve reports a record that was sent to
+// dns/create or dns/edit.
+func cachedRecord(domain, id string, record DnsRecord) DnsRecord {
+	record.ID = id
+	record.Type = strings.ToUpper(record.Type)
+	record.Name = recordFQDN(record.Name, domain)
+	if record.TTL == 0 {
+		record.TTL = 600
+	}
+	return record
+}
+
+// recordFQDN turns a subdomain as accepted by the API into the fully
+// qualified name the API reports.
+func recordFQDN(name, domain string) string {
+	switch name = strings.TrimSuffix(strings.ToLower(name), "."); {
+	case name == "" || name == "@" || name == domain:
+		return domain
+	case strings.HasSuffix(name, "."+domain):
+		return name
+	default:
+		return name + "." + domain
+	}
+}
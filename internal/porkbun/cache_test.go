@@ -0,0 +1,89 @@
+package porkbun
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheDiscardsStaleFill(t *testing.T) {
+	c := newCache[[]string]()
+
+	generation := c.generation("example.com")
+	c.invalidate("example.com")
+	if c.fill("example.com", generation, []string{"stale"}) {
+		t.Fatal("fill started before a write must be discarded")
+	}
+	if _, ok := c.get("example.com", 0); ok {
+		t.Fatal("stale fill ended up in the cache")
+	}
+
+	if !c.fill("example.com", c.generation("example.com"), []string{"fresh"}) {
+		t.Fatal("fill with current generation was discarded")
+	}
+	if v, ok := c.get("example.com", 0); !ok || v[0] != "fresh" {
+		t.Fatalf("get = %v, %v", v, ok)
+	}
+}
+
+func TestCacheUpdate(t *testing.T) {
+	c := newCache[[]string]()
+
+	c.update("example.com", func(v []string) []string { return append(v, "ignored") })
+	if _, ok := c.get("example.com", 0); ok {
+		t.Fatal("update must not create an entry")
+	}
+
+	c.fill("example.com", c.generation("example.com"), []string{"a"})
+	generation := c.generation("example.com")
+	c.update("example.com", func(v []string) []string { return append(v, "b") })
+	if v, _ := c.get("example.com", 0); len(v) != 2 || v[1] != "b" {
+		t.Fatalf("update not applied: %v", v)
+	}
+	if c.fill("example.com", generation, []string{"stale"}) {
+		t.Fatal("fill racing with an update must be discarded")
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := newCache[int]()
+	c.fill("k", c.generation("k"), 1)
+
+	if _, ok := c.get("k", time.Hour); !ok {
+		t.Fatal("entry expired too early")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.get("k", time.Millisecond); ok {
+		t.Fatal("entry should have expired")
+	}
+	if _, ok := c.get("k", 0); !ok {
+		t.Fatal("a zero TTL must never expire entries")
+	}
+}
+
+func TestCachedRecord(t *testing.T) {
+	tests := map[string]struct {
+		in   DnsRecord
+		want DnsRecord
+	}{
+		"apex": {
+			in:   DnsRecord{Type: "a", Content: "192.0.2.1"},
+			want: DnsRecord{ID: "1", Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 600, Prio: 0},
+		},
+		"subdomain": {
+			in:   DnsRecord{Name: "WWW", Type: "MX", Content: "mail.example.com", TTL: 3600, Prio: 10},
+			want: DnsRecord{ID: "1", Name: "www.example.com", Type: "MX", Content: "mail.example.com", TTL: 3600, Prio: 10},
+		},
+		"fqdn": {
+			in:   DnsRecord{Name: "www.example.com.", Type: "TXT", Content: "x"},
+			want: DnsRecord{ID: "1", Name: "www.example.com", Type: "TXT", Content: "x", TTL: 600, Prio: 0},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := cachedRecord("example.com", "1", tt.in); got != tt.want {
+				t.Errorf("cachedRecord = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,37 +4,64 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const defaultBaseURL = "https://api.porkbun.com/api/json/v3"
+const (
+	defaultBaseURL  = "https://api.porkbun.com/api/json/v3"
+	listAllPageSize = 1000
+	// domainListingAttempts bounds how often WaitForDomain polls
+	// domain/listAll, which adds up to about 1.5 minutes with the default
+	// backoff.
+	domainListingAttempts = 8
+)
 
 func NewClient(apiKey, secretKey string) *Client {
 	return &Client{
 		apiKey:          apiKey,
 		secretKey:       secretKey,
 		BaseURL:         defaultBaseURL,
-		HTTPClient:      &http.Client{},
-		recordsCache:    make(map[string][]DnsRecord),
-		pricingCache:    make(map[string]TldPricing),
-		glueRecordCache: make(map[string]map[string][]string),
-		dnssecCache:     make(map[string][]DnssecRecord),
-		domainListCache: nil,
+		HTTPClient:      &http.Client{Timeout: defaultRequestTimeout},
+		MaxRetries:      defaultMaxRetries,
+		MaxRetryWait:    defaultMaxRetryWait,
+		retryBaseDelay:  defaultRetryBaseDelay,
+		recordsCache:    newCache[[]DnsRecord](),
+		pricingCache:    newCache[map[string]TldPricing](),
+		glueRecordCache: newCache[map[string][]string](),
+		dnssecCache:     newCache[[]DnssecRecord](),
+		domainListCache: newCache[[]DomainListing](),
+		urlForwardCache: newCache[[]UrlForward](),
 	}
 }
 
-func (c *Client) clearDomainCache(domain string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	delete(c.recordsCache, domain)
-	delete(c.glueRecordCache, domain)
-	delete(c.dnssecCache, domain)
+// shared runs fn once for all concurrent callers using the same key and hands
+// every caller the same result. fn runs detached from the cancellation of any
+// single caller, so one aborted read does not fail the others; each caller
+// still stops waiting as soon as its own context is done.
+func (c *Client) shared(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	ch := c.flight.DoChan(key, func() (interface{}, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
-func (c *Client) newAuthenticatedRequest(method, url string, body interface{}) (*http.Request, error) {
+func (c *Client) newAuthenticatedRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
 	authBody := make(map[string]interface{})
 	if body != nil {
 		b, _ := json.Marshal(body)
@@ -48,26 +75,50 @@ func (c *Client) newAuthenticatedRequest(method, url string, body interface{}) (
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(rb))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(rb))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(c.logContext(ctx, req.URL))
+	tflog.SubsystemTrace(req.Context(), logSubsystem, "Prepared Porkbun API request", map[string]interface{}{
+		"method":       method,
+		"request_body": string(rb),
+	})
+
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "Terraform-Provider-Porkbun/0.0.1-SNAPSHOT")
 	return req, nil
 }
 
 func (c *Client) do(req *http.Request, v interface{}) error {
-	resp, err := c.HTTPClient.Do(req)
+	return c.doWithRetry(req, v, true)
+}
+
+// doNonIdempotent is used for calls that must not be replayed once the API
+// may have processed them, e.g. creating a DNS record.
+func (c *Client) doNonIdempotent(req *http.Request, v interface{}) error {
+	return c.doWithRetry(req, v, false)
+}
+
+func (c *Client) doOnce(req *http.Request, v interface{}) error {
+	release, err := c.acquire(req.Context())
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	defer release()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: status code %d, response: %s", resp.StatusCode, string(bodyBytes))
+	ctx := req.Context()
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Porkbun API request failed", map[string]interface{}{
+			"method":      req.Method,
+			"duration_ms": time.Since(start).Milliseconds(),
+			"error":       err.Error(),
+		})
+		return classifyTransportError(ctx, err)
 	}
+	defer resp.Body.Close()
 
 	var statusResponse struct {
 		Status  string `json:"status"`
@@ -76,7 +127,23 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return &retryableError{err: fmt.Errorf("failed to read response body: %w", err), mayHaveApplied: true}
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Porkbun API request completed", map[string]interface{}{
+		"method":      req.Method,
+		"status_code": resp.StatusCode,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	tflog.SubsystemTrace(ctx, logSubsystem, "Porkbun API response body", map[string]interface{}{
+		"response_body": string(bodyBytes),
+	})
+
+	if resp.StatusCode != http.StatusOK {
+		if json.Unmarshal(bodyBytes, &statusResponse) != nil || statusResponse.Message == "" {
+			statusResponse.Message = strings.TrimSpace(string(bodyBytes))
+		}
+		return classifyResponse(resp, c.newAPIError(req, resp.StatusCode, statusResponse.Status, statusResponse.Message))
 	}
 
 	if err := json.Unmarshal(bodyBytes, &statusResponse); err != nil {
@@ -84,7 +151,7 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 	}
 
 	if statusResponse.Status == "ERROR" {
-		return fmt.Errorf("Porkbun API error: %s", statusResponse.Message)
+		return classifyResponse(resp, c.newAPIError(req, resp.StatusCode, statusResponse.Status, statusResponse.Message))
 	}
 
 	if v != nil {
@@ -96,9 +163,9 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 	return nil
 }
 
-func (c *Client) CreateRecord(domain string, record DnsRecord) (string, error) {
+func (c *Client) CreateRecord(ctx context.Context, domain string, record DnsRecord) (string, error) {
 	url := fmt.Sprintf("%s/dns/create/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, record)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, record)
 	if err != nil {
 		return "", err
 	}
@@ -108,80 +175,254 @@ func (c *Client) CreateRecord(domain string, record DnsRecord) (string, error) {
 		ID     int    `json:"id"`
 	}
 
-	if err := c.do(req, &response); err != nil {
+	if err := c.doNonIdempotent(req, &response); err != nil {
+		c.recordsCache.invalidate(domain)
 		return "", err
 	}
-	c.clearDomainCache(domain)
-	return fmt.Sprintf("%d", response.ID), nil
+
+	id := fmt.Sprintf("%d", response.ID)
+	c.recordsCache.update(domain, func(records []DnsRecord) []DnsRecord {
+		return append(slices.Clip(records), cachedRecord(domain, id, record))
+	})
+	return id, nil
 }
 
-func (c *Client) RetrieveRecords(domain string) ([]DnsRecord, error) {
-	c.mu.Lock()
-	if cachedRecords, found := c.recordsCache[domain]; found {
-		c.mu.Unlock()
+func (c *Client) RetrieveRecords(ctx context.Context, domain string) ([]DnsRecord, error) {
+	if cachedRecords, found := c.recordsCache.get(domain, c.CacheTTL); found {
 		return cachedRecords, nil
 	}
-	c.mu.Unlock()
 
-	url := fmt.Sprintf("%s/dns/retrieve/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	v, err := c.shared(ctx, "dns/retrieve/"+domain, func(ctx context.Context) (interface{}, error) {
+		generation := c.recordsCache.generation(domain)
+
+		url := fmt.Sprintf("%s/dns/retrieve/%s", c.BaseURL, domain)
+		req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response RetrieveRecordsResponse
+
+		if err := c.do(req, &response); err != nil {
+			return nil, err
+		}
+
+		c.recordsCache.fill(domain, generation, response.Records)
+		return response.Records, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return v.([]DnsRecord), nil
+}
 
-	var response struct {
-		Status  string      `json:"status"`
-		Records []DnsRecord `json:"records"`
+// RetrieveRecord returns the record with the given ID, or ErrNotFound. It is
+// looked up in the zone from RetrieveRecords, so refreshing many records of a
+// domain costs a single request. Only if the zone cannot be listed is the
+// single record fetched instead.
+func (c *Client) RetrieveRecord(ctx context.Context, domain, recordID string) (*DnsRecord, error) {
+	records, err := c.RetrieveRecords(ctx, domain)
+	if err == nil {
+		for _, record := range records {
+			if record.ID == recordID {
+				return &record, nil
+			}
+		}
+		return nil, ErrNotFound
 	}
-
-	if err := c.do(req, &response); err != nil {
+	if ctx.Err() != nil || errors.Is(err, ErrAuthFailed) || errors.Is(err, ErrAPIAccessDisabled) {
 		return nil, err
 	}
 
-	c.mu.Lock()
-	c.recordsCache[domain] = response.Records
-	c.mu.Unlock()
+	tflog.SubsystemDebug(ctx, logSubsystem, "Listing DNS records failed, fetching the single record", map[string]interface{}{
+		"domain": domain,
+		"error":  err.Error(),
+	})
+	url := fmt.Sprintf("%s/dns/retrieve/%s/%s", c.BaseURL, domain, recordID)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	return response.Records, nil
+	var response RetrieveRecordsResponse
+	if err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+	for _, record := range response.Records {
+		if record.ID == recordID {
+			return &record, nil
+		}
+	}
+	return nil, ErrNotFound
 }
 
-func (c *Client) DeleteRecord(domain, recordID string) error {
+func (c *Client) DeleteRecord(ctx context.Context, domain, recordID string) error {
 	url := fmt.Sprintf("%s/dns/delete/%s/%s", c.BaseURL, domain, recordID)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return err
 	}
-	c.clearDomainCache(domain)
-	return c.do(req, nil)
+
+	err = c.do(req, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		c.recordsCache.invalidate(domain)
+		return err
+	}
+	c.recordsCache.update(domain, func(records []DnsRecord) []DnsRecord {
+		return slices.DeleteFunc(slices.Clone(records), func(r DnsRecord) bool { return r.ID == recordID })
+	})
+	return err
 }
 
-func (c *Client) EditRecord(domain, recordID string, record DnsRecord) error {
+func (c *Client) EditRecord(ctx context.Context, domain, recordID string, record DnsRecord) error {
 	url := fmt.Sprintf("%s/dns/edit/%s/%s", c.BaseURL, domain, recordID)
 
+	// Notes are always sent, since leaving them out clears them.
 	payload := map[string]string{
 		"name":    record.Name,
 		"type":    record.Type,
 		"content": record.Content,
-		"ttl":     record.TTL,
+		"notes":   record.Notes,
+	}
+	if record.TTL != 0 {
+		payload["ttl"] = record.TTL.String()
 	}
 
 	recordType := strings.ToUpper(record.Type)
-	if (recordType == "MX" || recordType == "SRV") && record.Prio != "" {
-		payload["prio"] = record.Prio
+	if (recordType == "MX" || recordType == "SRV") && record.Prio != 0 {
+		payload["prio"] = record.Prio.String()
+	} else {
+		record.Prio = 0
 	}
 
-	req, err := c.newAuthenticatedRequest("POST", url, payload)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
 	if err != nil {
 		return err
 	}
-	c.clearDomainCache(domain)
-	return c.do(req, nil)
 
+	if err := c.do(req, nil); err != nil {
+		c.recordsCache.invalidate(domain)
+		return err
+	}
+
+	c.recordsCache.update(domain, func(records []DnsRecord) []DnsRecord {
+		records = slices.Clone(records)
+		for i := range records {
+			if records[i].ID == recordID {
+				records[i] = cachedRecord(domain, recordID, record)
+			}
+		}
+		return records
+	})
+	return nil
+}
+
+// RetrieveRecordsByNameType returns the records of recordType at subdomain,
+// which is empty for the domain itself. A cached zone is used when present.
+func (c *Client) RetrieveRecordsByNameType(ctx context.Context, domain, recordType, subdomain string) ([]DnsRecord, error) {
+	if cachedRecords, found := c.recordsCache.get(domain, c.CacheTTL); found {
+		return filterByNameType(cachedRecords, domain, recordType, subdomain), nil
+	}
+
+	req, err := c.newAuthenticatedRequest(ctx, "POST", c.nameTypeURL("dns/retrieveByNameType", domain, recordType, subdomain), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response RetrieveRecordsResponse
+	if err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+	return response.Records, nil
+}
+
+// EditRecordsByNameType sets content, ttl, prio and notes of all records of
+// recordType at subdomain.
+func (c *Client) EditRecordsByNameType(ctx context.Context, domain, recordType, subdomain string, record DnsRecord) error {
+	// Notes are always sent, like in EditRecord, so that empty notes clear
+	// them.
+	payload := map[string]string{
+		"content": record.Content,
+		"notes":   record.Notes,
+	}
+	if record.TTL != 0 {
+		payload["ttl"] = record.TTL.String()
+	}
+	if record.Prio != 0 {
+		payload["prio"] = record.Prio.String()
+	}
+
+	req, err := c.newAuthenticatedRequest(ctx, "POST", c.nameTypeURL("dns/editByNameType", domain, recordType, subdomain), payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(req, nil); err != nil {
+		c.recordsCache.invalidate(domain)
+		return err
+	}
+
+	c.recordsCache.update(domain, func(records []DnsRecord) []DnsRecord {
+		records = slices.Clone(records)
+		for _, match := range filterByNameType(records, domain, recordType, subdomain) {
+			i := slices.IndexFunc(records, func(r DnsRecord) bool { return r.ID == match.ID })
+			records[i] = cachedRecord(domain, match.ID, DnsRecord{
+				Name:    match.Name,
+				Type:    match.Type,
+				Content: record.Content,
+				TTL:     record.TTL,
+				Prio:    record.Prio,
+				Notes:   record.Notes,
+			})
+		}
+		return records
+	})
+	return nil
+}
+
+// DeleteRecordsByNameType deletes all records of recordType at subdomain.
+func (c *Client) DeleteRecordsByNameType(ctx context.Context, domain, recordType, subdomain string) error {
+	req, err := c.newAuthenticatedRequest(ctx, "POST", c.nameTypeURL("dns/deleteByNameType", domain, recordType, subdomain), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(req, nil); err != nil {
+		c.recordsCache.invalidate(domain)
+		return err
+	}
+
+	c.recordsCache.update(domain, func(records []DnsRecord) []DnsRecord {
+		name := recordFQDN(subdomain, domain)
+		return slices.DeleteFunc(slices.Clone(records), func(r DnsRecord) bool {
+			return strings.EqualFold(r.Name, name) && strings.EqualFold(r.Type, recordType)
+		})
+	})
+	return nil
+}
+
+func (c *Client) nameTypeURL(endpoint, domain, recordType, subdomain string) string {
+	url := fmt.Sprintf("%s/%s/%s/%s", c.BaseURL, endpoint, domain, strings.ToUpper(recordType))
+	if subdomain = strings.TrimSuffix(strings.TrimSuffix(subdomain, "."), "."+domain); subdomain != "" && subdomain != "@" && subdomain != domain {
+		url += "/" + subdomain
+	}
+	return url
+}
+
+func filterByNameType(records []DnsRecord, domain, recordType, subdomain string) []DnsRecord {
+	name := recordFQDN(subdomain, domain)
+	var matches []DnsRecord
+	for _, r := range records {
+		if strings.EqualFold(r.Name, name) && strings.EqualFold(r.Type, recordType) {
+			matches = append(matches, r)
+		}
+	}
+	return matches
 }
 
 func (c *Client) Ping(ctx context.Context) (string, error) {
 	url := fmt.Sprintf("%s/ping", c.BaseURL)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return "", err
 	}
@@ -194,52 +435,53 @@ func (c *Client) Ping(ctx context.Context) (string, error) {
 	return response.YourIP, nil
 }
 
-func (c *Client) GetPricing() (map[string]TldPricing, error) {
-	c.mu.Lock()
-	if len(c.pricingCache) > 0 {
-		c.mu.Unlock()
-		return c.pricingCache, nil
+func (c *Client) GetPricing(ctx context.Context) (map[string]TldPricing, error) {
+	if cachedPricing, found := c.pricingCache.get("", c.CacheTTL); found {
+		return cachedPricing, nil
 	}
-	c.mu.Unlock()
 
-	url := fmt.Sprintf("%s/pricing/get", c.BaseURL)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
-	if err != nil {
-		return nil, err
-	}
+	v, err := c.shared(ctx, "pricing/get", func(ctx context.Context) (interface{}, error) {
+		generation := c.pricingCache.generation("")
 
-	var response PricingResponse
-	if err := c.do(req, &response); err != nil {
-		return nil, err
-	}
+		url := fmt.Sprintf("%s/pricing/get", c.BaseURL)
+		req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	c.mu.Lock()
-	c.pricingCache = response.Pricing
-	c.mu.Unlock()
+		var response PricingResponse
+		if err := c.do(req, &response); err != nil {
+			return nil, err
+		}
 
-	return response.Pricing, nil
+		c.pricingCache.fill("", generation, response.Pricing)
+		return response.Pricing, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]TldPricing), nil
 }
 
-func (c *Client) UpdateNameservers(domain string, nameservers []string) error {
+func (c *Client) UpdateNameservers(ctx context.Context, domain string, nameservers []string) error {
 	url := fmt.Sprintf("%s/domain/updateNs/%s", c.BaseURL, domain)
 
 	payload := map[string][]string{
 		"ns": nameservers,
 	}
 
-	req, err := c.newAuthenticatedRequest("POST", url, payload)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
 	if err != nil {
 		return err
 	}
 
-	c.clearDomainCache(domain)
 	return c.do(req, nil)
 }
 
-func (c *Client) GetNameservers(domain string) ([]string, error) {
+func (c *Client) GetNameservers(ctx context.Context, domain string) ([]string, error) {
 	url := fmt.Sprintf("%s/domain/getNs/%s", c.BaseURL, domain)
 
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -252,164 +494,383 @@ func (c *Client) GetNameservers(domain string) ([]string, error) {
 	return response.NS, nil
 }
 
-func (c *Client) AddGlueRecord(domain, host string, ips []string) error {
+func (c *Client) AddGlueRecord(ctx context.Context, domain, host string, ips []string) error {
 	url := fmt.Sprintf("%s/domain/createGlue/%s/%s", c.BaseURL, domain, host)
 	payload := map[string]interface{}{
 		"ips": ips,
 	}
-	req, err := c.newAuthenticatedRequest("POST", url, payload)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
 	if err != nil {
 		return err
 	}
 
-	err = c.do(req, nil)
-	if err == nil {
-		c.clearDomainCache(domain)
+	if err := c.doNonIdempotent(req, nil); err != nil {
+		c.glueRecordCache.invalidate(domain)
+		return err
 	}
-	return err
+
+	// getGlue lists IPv4 addresses before IPv6 addresses.
+	var v4, v6 []string
+	for _, ip := range ips {
+		if strings.Contains(ip, ":") {
+			v6 = append(v6, ip)
+		} else {
+			v4 = append(v4, ip)
+		}
+	}
+	c.glueRecordCache.update(domain, func(hosts map[string][]string) map[string][]string {
+		hosts = maps.Clone(hosts)
+		hosts[host] = append(v4, v6...)
+		return hosts
+	})
+	return nil
 }
 
-func (c *Client) DeleteGlueRecord(domain, host string) error {
+func (c *Client) DeleteGlueRecord(ctx context.Context, domain, host string) error {
 	url := fmt.Sprintf("%s/domain/deleteGlue/%s/%s", c.BaseURL, domain, host)
 
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return err
 	}
 
 	err = c.do(req, nil)
-	if err == nil {
-		c.clearDomainCache(domain)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		c.glueRecordCache.invalidate(domain)
+		return err
 	}
+	c.glueRecordCache.update(domain, func(hosts map[string][]string) map[string][]string {
+		hosts = maps.Clone(hosts)
+		delete(hosts, host)
+		return hosts
+	})
 	return err
 }
 
-func (c *Client) GetGlueRecords(domain string) (map[string][]string, error) {
-	c.mu.Lock()
-	if cachedRecords, found := c.glueRecordCache[domain]; found {
-		c.mu.Unlock()
+func (c *Client) GetGlueRecords(ctx context.Context, domain string) (map[string][]string, error) {
+	if cachedRecords, found := c.glueRecordCache.get(domain, c.CacheTTL); found {
 		return cachedRecords, nil
 	}
-	c.mu.Unlock()
 
-	url := fmt.Sprintf("%s/domain/getGlue/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+	v, err := c.shared(ctx, "domain/getGlue/"+domain, func(ctx context.Context) (interface{}, error) {
+		generation := c.glueRecordCache.generation(domain)
+
+		url := fmt.Sprintf("%s/domain/getGlue/%s", c.BaseURL, domain)
+		req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response GetGlueRecordsResponse
+		if err := c.do(req, &response); err != nil {
+			return nil, err
+		}
+
+		parsedRecords := make(map[string][]string, len(response.Hosts))
+		for _, host := range response.Hosts {
+			parsedRecords[strings.TrimSuffix(host.Host, "."+domain)] = host.IPs()
+		}
+
+		c.glueRecordCache.fill(domain, generation, parsedRecords)
+		return parsedRecords, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return v.(map[string][]string), nil
+}
 
-	var response GetGlueRecordsResponse
-	if err := c.do(req, &response); err != nil {
-		return nil, err
+func (c *Client) GetDnssecRecords(ctx context.Context, domain string) ([]DnssecRecord, error) {
+	if cachedRecords, found := c.dnssecCache.get(domain, c.CacheTTL); found {
+		return cachedRecords, nil
 	}
 
-	parsedRecords := make(map[string][]string)
-	for _, hostData := range response.Hosts {
-		if len(hostData) != 2 {
-			continue
-		}
-		fqdn, ok := hostData[0].(string)
-		if !ok {
-			continue
-		}
-		host := strings.TrimSuffix(fqdn, "."+domain)
+	v, err := c.shared(ctx, "dns/getDnssec/"+domain, func(ctx context.Context) (interface{}, error) {
+		generation := c.dnssecCache.generation(domain)
 
-		ipMapBytes, err := json.Marshal(hostData[1])
+		url := fmt.Sprintf("%s/dns/getDnssec/%s", c.BaseURL, domain)
+		req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 		if err != nil {
-			continue
+			return nil, err
 		}
-		var ips GlueRecordIPs
-		if err := json.Unmarshal(ipMapBytes, &ips); err != nil {
-			continue
+
+		var response GetDnssecResponse
+		if err := c.do(req, &response); err != nil {
+			return nil, err
 		}
 
-		allIPs := append(ips.V4, ips.V6...)
-		parsedRecords[host] = allIPs
+		c.dnssecCache.fill(domain, generation, response.DsRecords)
+		return response.DsRecords, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return v.([]DnssecRecord), nil
+}
 
-	c.mu.Lock()
-	c.glueRecordCache[domain] = parsedRecords
-	c.mu.Unlock()
+func (c *Client) AddDnssecRecord(ctx context.Context, domain string, record DnssecRecord) error {
+	url := fmt.Sprintf("%s/dns/addDnssec/%s", c.BaseURL, domain)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, record)
+	if err != nil {
+		return err
+	}
 
-	return parsedRecords, nil
+	if err := c.doNonIdempotent(req, nil); err != nil {
+		c.dnssecCache.invalidate(domain)
+		return err
+	}
+	c.dnssecCache.update(domain, func(records []DnssecRecord) []DnssecRecord {
+		return append(slices.Clip(records), record)
+	})
+	return nil
 }
 
-func (c *Client) GetDnssecRecords(domain string) ([]DnssecRecord, error) {
-	c.mu.Lock()
-	if cachedRecords, found := c.dnssecCache[domain]; found {
-		c.mu.Unlock()
-		return cachedRecords, nil
+func (c *Client) DeleteDnssecRecord(ctx context.Context, domain string, record DnssecRecord) error {
+	url := fmt.Sprintf("%s/dns/deleteDnssec/%s", c.BaseURL, domain)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, record)
+	if err != nil {
+		return err
+	}
+
+	err = c.do(req, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		c.dnssecCache.invalidate(domain)
+		return err
 	}
-	c.mu.Unlock()
+	c.dnssecCache.update(domain, func(records []DnssecRecord) []DnssecRecord {
+		return slices.DeleteFunc(slices.Clone(records), func(r DnssecRecord) bool { return r == record })
+	})
+	return err
+}
+
+func (c *Client) ListAllDomains(ctx context.Context) ([]DomainListing, error) {
+	if cachedDomains, found := c.domainListCache.get("", c.CacheTTL); found {
+		return cachedDomains, nil
+	}
+
+	v, err := c.shared(ctx, "domain/listAll", func(ctx context.Context) (interface{}, error) {
+		generation := c.domainListCache.generation("")
+
+		// The API returns at most listAllPageSize domains per call.
+		var domains []DomainListing
+		for start := 0; ; start += listAllPageSize {
+			url := fmt.Sprintf("%s/domain/listAll", c.BaseURL)
+			payload := map[string]string{
+				"start":         strconv.Itoa(start),
+				"includeLabels": "yes",
+			}
+			req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
+			if err != nil {
+				return nil, err
+			}
+
+			var response ListAllResponse
+			if err := c.do(req, &response); err != nil {
+				return nil, err
+			}
+
+			domains = append(domains, response.Domains...)
+			if len(response.Domains) < listAllPageSize {
+				break
+			}
+		}
 
-	url := fmt.Sprintf("%s/dns/getDnssec/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+		c.domainListCache.fill("", generation, domains)
+		return domains, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return v.([]DomainListing), nil
+}
 
-	var response GetDnssecResponse
-	if err := c.do(req, &response); err != nil {
-		return nil, err
+func (c *Client) GetUrlForwarding(ctx context.Context, domain string) ([]UrlForward, error) {
+	if cachedForwards, found := c.urlForwardCache.get(domain, c.CacheTTL); found {
+		return cachedForwards, nil
 	}
 
-	c.mu.Lock()
-	c.dnssecCache[domain] = response.DsRecords
-	c.mu.Unlock()
+	v, err := c.shared(ctx, "domain/getUrlForwarding/"+domain, func(ctx context.Context) (interface{}, error) {
+		generation := c.urlForwardCache.generation(domain)
+
+		url := fmt.Sprintf("%s/domain/getUrlForwarding/%s", c.BaseURL, domain)
+		req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response GetUrlForwardingResponse
+		if err := c.do(req, &response); err != nil {
+			return nil, err
+		}
 
-	return response.DsRecords, nil
+		c.urlForwardCache.fill(domain, generation, response.Forwards)
+		return response.Forwards, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]UrlForward), nil
 }
 
-func (c *Client) AddDnssecRecord(domain string, record DnssecRecord) error {
-	url := fmt.Sprintf("%s/dns/addDnssec/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, record)
+// AddUrlForward creates forward and returns its ID. The API does not return
+// the ID, so it is looked up afterwards by comparing the forwards before and
+// after the call.
+func (c *Client) AddUrlForward(ctx context.Context, domain string, forward UrlForward) (string, error) {
+	before, err := c.GetUrlForwarding(ctx, domain)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	err = c.do(req, nil)
-	if err == nil {
-		c.clearDomainCache(domain)
+	url := fmt.Sprintf("%s/domain/addUrlForward/%s", c.BaseURL, domain)
+	payload := map[string]string{
+		"subdomain":   forward.Subdomain,
+		"location":    forward.Location,
+		"type":        forward.Type,
+		"includePath": yesNo(forward.IncludePath),
+		"wildcard":    yesNo(forward.Wildcard),
 	}
-	return err
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.doNonIdempotent(req, nil)
+	c.urlForwardCache.invalidate(domain)
+	if err != nil {
+		return "", err
+	}
+
+	after, err := c.GetUrlForwarding(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range after {
+		known := slices.ContainsFunc(before, func(b UrlForward) bool { return b.ID == f.ID })
+		if !known && f.matches(forward) {
+			return f.ID, nil
+		}
+	}
+	return "", fmt.Errorf("porkbun: URL forward to %s was created for %s but could not be found afterwards", forward.Location, domain)
 }
 
-func (c *Client) DeleteDnssecRecord(domain string, record DnssecRecord) error {
-	url := fmt.Sprintf("%s/dns/deleteDnssec/%s", c.BaseURL, domain)
-	req, err := c.newAuthenticatedRequest("POST", url, record)
+func (c *Client) DeleteUrlForward(ctx context.Context, domain, forwardID string) error {
+	url := fmt.Sprintf("%s/domain/deleteUrlForward/%s/%s", c.BaseURL, domain, forwardID)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return err
 	}
 
 	err = c.do(req, nil)
-	if err == nil {
-		c.clearDomainCache(domain)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		c.urlForwardCache.invalidate(domain)
+		return err
 	}
+	c.urlForwardCache.update(domain, func(forwards []UrlForward) []UrlForward {
+		return slices.DeleteFunc(slices.Clone(forwards), func(f UrlForward) bool { return f.ID == forwardID })
+	})
 	return err
 }
 
-func (c *Client) ListAllDomains() ([]DomainListing, error) {
-	c.mu.Lock()
-	if c.domainListCache != nil {
-		c.mu.Unlock()
-		return c.domainListCache, nil
+func (f UrlForward) matches(other UrlForward) bool {
+	return strings.EqualFold(f.Subdomain, other.Subdomain) &&
+		f.Location == other.Location &&
+		strings.EqualFold(f.Type, other.Type) &&
+		f.IncludePath == other.IncludePath &&
+		f.Wildcard == other.Wildcard
+}
+
+func yesNo(b FlexBool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// RetrieveSslBundle returns the free certificate Porkbun issued for domain,
+// including its private key. The result is not cached.
+func (c *Client) RetrieveSslBundle(ctx context.Context, domain string) (*SslBundle, error) {
+	url := fmt.Sprintf("%s/ssl/retrieve/%s", c.BaseURL, domain)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle SslBundle
+	if err := c.do(req, &bundle); err != nil {
+		return nil, err
 	}
-	c.mu.Unlock()
+	return &bundle, nil
+}
 
-	url := fmt.Sprintf("%s/domain/listAll", c.BaseURL)
-	req, err := c.newAuthenticatedRequest("POST", url, nil)
+// CheckDomain checks whether domain can be registered and at which price.
+// Porkbun allows only a few checks per minute; the Limits of the response
+// tell how many are left.
+func (c *Client) CheckDomain(ctx context.Context, domain string) (*CheckDomainResponse, error) {
+	url := fmt.Sprintf("%s/domain/checkDomain/%s", c.BaseURL, domain)
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var response ListAllResponse
+	var response CheckDomainResponse
 	if err := c.do(req, &response); err != nil {
 		return nil, err
 	}
+	return &response, nil
+}
+
+// CreateDomain registers domain for the given number of years and charges the
+// account balance. cost is the expected total in cents; the API refuses the
+// order if it does not match. The call is only retried when the API cannot
+// have processed it, so a lost response never leads to a second charge.
+func (c *Client) CreateDomain(ctx context.Context, domain string, cost int64, years int64) (*CreateDomainResponse, error) {
+	url := fmt.Sprintf("%s/domain/create/%s", c.BaseURL, domain)
+	payload := map[string]interface{}{
+		"cost":         cost,
+		"years":        years,
+		"agreeToTerms": "yes",
+	}
+	req, err := c.newAuthenticatedRequest(ctx, "POST", url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response CreateDomainResponse
+	err = c.doNonIdempotent(req, &response)
+	c.domainListCache.invalidate("")
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
 
-	c.mu.Lock()
-	c.domainListCache = response.Domains
-	c.mu.Unlock()
+// WaitForDomain returns the listing of domain from domain/listAll. A freshly
+// registered domain can take a moment to show up there, so the listing is
+// polled with the retry backoff, bypassing the cache. It returns ErrNotFound
+// if the domain is still missing after domainListingAttempts tries.
+func (c *Client) WaitForDomain(ctx context.Context, domain string) (*DomainListing, error) {
+	for attempt := 0; ; attempt++ {
+		c.domainListCache.invalidate("")
+		domains, err := c.ListAllDomains(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range domains {
+			if strings.EqualFold(domains[i].Domain, domain) {
+				return &domains[i], nil
+			}
+		}
+		if attempt+1 >= domainListingAttempts {
+			return nil, ErrNotFound
+		}
 
-	return response.Domains, nil
+		wait := c.backoff(attempt, 0)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Domain not listed yet, waiting", map[string]interface{}{
+			"domain":  domain,
+			"wait_ms": wait.Milliseconds(),
+		})
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 }
@@ -0,0 +1,638 @@
+package porkbun_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func newFakeClient(t *testing.T) (*porkbun.Client, *porkbuntest.Server) {
+	t.Helper()
+	server := porkbuntest.NewServer(t)
+	server.AddDomain(porkbuntest.Domain{Name: "example.com"})
+
+	client := server.Client()
+	client.MaxRetryWait = 10 * time.Millisecond
+	return client, server
+}
+
+func TestRecordLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	id, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 600})
+	if err != nil {
+		t.Fatalf("CreateRecord: %s", err)
+	}
+
+	records, err := client.RetrieveRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("RetrieveRecords: %s", err)
+	}
+	if len(records) != 1 || records[0].ID != id || records[0].Name != "www.example.com" {
+		t.Fatalf("unexpected records after create: %+v", records)
+	}
+
+	if err := client.EditRecord(ctx, "example.com", id, porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.2", TTL: 600, Notes: "TICKET-1"}); err != nil {
+		t.Fatalf("EditRecord: %s", err)
+	}
+	d, _ := server.Domain("example.com")
+	if d.Records[0].Content != "192.0.2.2" || d.Records[0].Notes != "TICKET-1" {
+		t.Errorf("edit not applied: %+v", d.Records[0])
+	}
+
+	if err := client.DeleteRecord(ctx, "example.com", id); err != nil {
+		t.Fatalf("DeleteRecord: %s", err)
+	}
+	if err := client.DeleteRecord(ctx, "example.com", id); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("second delete: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNameserversAndGlue(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	ns := []string{"ns1.example.com", "ns2.example.com"}
+	if err := client.UpdateNameservers(ctx, "example.com", ns); err != nil {
+		t.Fatalf("UpdateNameservers: %s", err)
+	}
+	got, err := client.GetNameservers(ctx, "example.com")
+	if err != nil || !reflect.DeepEqual(got, ns) {
+		t.Fatalf("GetNameservers = %v, %v", got, err)
+	}
+
+	if err := client.AddGlueRecord(ctx, "example.com", "ns1", []string{"192.0.2.1", "2001:db8::1"}); err != nil {
+		t.Fatalf("AddGlueRecord: %s", err)
+	}
+	glue, err := client.GetGlueRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("GetGlueRecords: %s", err)
+	}
+	if want := []string{"192.0.2.1", "2001:db8::1"}; !reflect.DeepEqual(glue["ns1"], want) {
+		t.Errorf("glue ns1 = %v, want %v", glue["ns1"], want)
+	}
+
+	if err := client.DeleteGlueRecord(ctx, "example.com", "ns1"); err != nil {
+		t.Fatalf("DeleteGlueRecord: %s", err)
+	}
+	if err := client.DeleteGlueRecord(ctx, "example.com", "ns1"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("second delete: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDnssecRecords(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	ds := porkbun.DnssecRecord{KeyTag: "12345", Algorithm: "13", DigestType: "2", Digest: "ABCDEF"}
+	if err := client.AddDnssecRecord(ctx, "example.com", ds); err != nil {
+		t.Fatalf("AddDnssecRecord: %s", err)
+	}
+	records, err := client.GetDnssecRecords(ctx, "example.com")
+	if err != nil || len(records) != 1 || records[0] != ds {
+		t.Fatalf("GetDnssecRecords = %v, %v", records, err)
+	}
+	if err := client.DeleteDnssecRecord(ctx, "example.com", ds); err != nil {
+		t.Fatalf("DeleteDnssecRecord: %s", err)
+	}
+}
+
+func TestAccountEndpoints(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	if ip, err := client.Ping(ctx); err != nil || ip != "127.0.0.1" {
+		t.Errorf("Ping = %q, %v", ip, err)
+	}
+	if pricing, err := client.GetPricing(ctx); err != nil || pricing["com"].Registration == "" {
+		t.Errorf("GetPricing = %v, %v", pricing, err)
+	}
+	domains, err := client.ListAllDomains(ctx)
+	if err != nil || len(domains) != 1 || domains[0].Domain != "example.com" {
+		t.Errorf("ListAllDomains = %v, %v", domains, err)
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if _, err := client.GetNameservers(ctx, "unknown.com"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("unknown domain: expected ErrNotFound, got %v", err)
+	}
+
+	server.UpdateDomain("example.com", func(d *porkbuntest.Domain) { d.APIAccessDisabled = true })
+	if _, err := client.GetNameservers(ctx, "example.com"); !errors.Is(err, porkbun.ErrAPIAccessDisabled) {
+		t.Errorf("expected ErrAPIAccessDisabled, got %v", err)
+	}
+
+	badClient := porkbun.NewClient("pk1_wrong", "sk1_wrong")
+	badClient.BaseURL = server.URL
+	if _, err := badClient.Ping(ctx); !errors.Is(err, porkbun.ErrAuthFailed) {
+		t.Errorf("expected ErrAuthFailed, got %v", err)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	server.InjectFault(porkbuntest.RateLimitFault("dns/create", 2, 0))
+	if _, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Type: "A", Content: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateRecord should succeed after rate limiting: %s", err)
+	}
+	if n := server.RequestCount("dns/create"); n != 3 {
+		t.Errorf("expected 3 dns/create requests, got %d", n)
+	}
+
+	server.InjectFault(porkbuntest.ServerErrorFault("dns/create", 1, http.StatusServiceUnavailable))
+	if _, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Type: "A", Content: "192.0.2.2"}); err == nil {
+		t.Error("CreateRecord must not be replayed after a 5xx response")
+	}
+
+	server.InjectFault(porkbuntest.MalformedJSONFault("ping", 1))
+	if _, err := client.Ping(ctx); err == nil {
+		t.Error("expected decode error for malformed JSON")
+	}
+
+	client.MaxRetries = 0
+	server.InjectFault(porkbuntest.RateLimitFault("", -1, 0))
+	if _, err := client.Ping(ctx); !errors.Is(err, porkbun.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestConcurrentCacheMissesShareOneRequest(t *testing.T) {
+	reads := map[string]func(context.Context, *porkbun.Client) error{
+		"dns/retrieve": func(ctx context.Context, c *porkbun.Client) error {
+			_, err := c.RetrieveRecords(ctx, "example.com")
+			return err
+		},
+		"domain/getGlue": func(ctx context.Context, c *porkbun.Client) error {
+			_, err := c.GetGlueRecords(ctx, "example.com")
+			return err
+		},
+		"dns/getDnssec": func(ctx context.Context, c *porkbun.Client) error {
+			_, err := c.GetDnssecRecords(ctx, "example.com")
+			return err
+		},
+		"domain/listAll": func(ctx context.Context, c *porkbun.Client) error {
+			_, err := c.ListAllDomains(ctx)
+			return err
+		},
+	}
+
+	for endpoint, read := range reads {
+		t.Run(endpoint, func(t *testing.T) {
+			client, server := newFakeClient(t)
+			server.OnRequest = func(porkbuntest.Request) { time.Sleep(50 * time.Millisecond) }
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := read(context.Background(), client); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if n := server.RequestCount(endpoint); n != 1 {
+				t.Errorf("expected 1 %s request for 50 concurrent reads, got %d", endpoint, n)
+			}
+		})
+	}
+}
+
+func TestSharedRequestSurvivesCallerCancel(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.OnRequest = func(porkbuntest.Request) { time.Sleep(50 * time.Millisecond) }
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.RetrieveRecords(cancelled, "example.com")
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.RetrieveRecords(context.Background(), "example.com")
+		done <- err
+	}()
+	cancel()
+
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller: expected context.Canceled, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("second caller should not be affected by the first caller's cancellation: %s", err)
+	}
+	if n := server.RequestCount("dns/retrieve"); n != 1 {
+		t.Errorf("expected 1 dns/retrieve request, got %d", n)
+	}
+}
+
+func TestMutationsUpdateCachedZone(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if _, err := client.RetrieveRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	www, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.1", Notes: "created"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mx, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Type: "MX", Content: "mail.example.com", Prio: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.EditRecord(ctx, "example.com", www, porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.2", TTL: 900, Notes: "edited"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteRecord(ctx, "example.com", mx); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := client.RetrieveRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := server.RequestCount("dns/retrieve"); n != 1 {
+		t.Errorf("expected the zone to be downloaded once, got %d dns/retrieve requests", n)
+	}
+
+	// The cached view has to match what a fresh client reads from the API.
+	fresh, err := server.Client().RetrieveRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cached, fresh) {
+		t.Errorf("cached zone diverged from the API:\ncached: %+v\nfresh:  %+v", cached, fresh)
+	}
+}
+
+func TestMutationsUpdateCachedGlueAndDnssec(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if _, err := client.GetGlueRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddGlueRecord(ctx, "example.com", "ns1", []string{"2001:db8::1", "192.0.2.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddGlueRecord(ctx, "example.com", "ns2", []string{"192.0.2.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteGlueRecord(ctx, "example.com", "ns2"); err != nil {
+		t.Fatal(err)
+	}
+	glue, _ := client.GetGlueRecords(ctx, "example.com")
+	fresh, _ := server.Client().GetGlueRecords(ctx, "example.com")
+	if !reflect.DeepEqual(glue, fresh) {
+		t.Errorf("cached glue = %v, API has %v", glue, fresh)
+	}
+	if n := server.RequestCount("domain/getGlue"); n != 2 {
+		t.Errorf("expected 1 cached and 1 fresh domain/getGlue request, got %d", n)
+	}
+
+	ds := porkbun.DnssecRecord{KeyTag: "12345", Algorithm: "13", DigestType: "2", Digest: "ABCDEF"}
+	if _, err := client.GetDnssecRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddDnssecRecord(ctx, "example.com", ds); err != nil {
+		t.Fatal(err)
+	}
+	if records, _ := client.GetDnssecRecords(ctx, "example.com"); len(records) != 1 || records[0] != ds {
+		t.Errorf("cached DS records after add = %v", records)
+	}
+	if err := client.DeleteDnssecRecord(ctx, "example.com", ds); err != nil {
+		t.Fatal(err)
+	}
+	if records, _ := client.GetDnssecRecords(ctx, "example.com"); len(records) != 0 {
+		t.Errorf("cached DS records after delete = %v", records)
+	}
+	if n := server.RequestCount("dns/getDnssec"); n != 1 {
+		t.Errorf("expected 1 dns/getDnssec request, got %d", n)
+	}
+}
+
+func TestFailedMutationInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if _, err := client.RetrieveRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	server.InjectFault(porkbuntest.ServerErrorFault("dns/create", 1, http.StatusBadGateway))
+	if _, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Type: "A", Content: "192.0.2.1"}); err == nil {
+		t.Fatal("expected CreateRecord to fail")
+	}
+	if _, err := client.RetrieveRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if n := server.RequestCount("dns/retrieve"); n != 2 {
+		t.Errorf("expected the zone to be re-read after a failed create, got %d dns/retrieve requests", n)
+	}
+}
+
+func TestDeleteDoesNotRaceWithConcurrentRead(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	id, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Type: "A", Content: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A read that is answered before the delete lands but delivered after it
+	// must not put the deleted record back into the cache.
+	answered, deliver := make(chan struct{}), make(chan struct{})
+	var hold sync.Once
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if strings.Contains(r.URL.Path, "/dns/retrieve/") {
+			hold.Do(func() {
+				close(answered)
+				<-deliver
+			})
+		}
+		return resp, err
+	})}
+	read := make(chan error, 1)
+	go func() {
+		_, err := client.RetrieveRecords(ctx, "example.com")
+		read <- err
+	}()
+	<-answered
+
+	if err := client.DeleteRecord(ctx, "example.com", id); err != nil {
+		t.Fatal(err)
+	}
+	close(deliver)
+	if err := <-read; err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := client.RetrieveRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if r.ID == id {
+			t.Fatalf("deleted record %s is back in the cache", id)
+		}
+	}
+}
+
+func TestListAllDomainsPagination(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	total := 2*porkbuntest.ListAllPageSize + 17
+	for i := 1; i < total; i++ {
+		server.AddDomain(porkbuntest.Domain{Name: fmt.Sprintf("domain%04d.com", i)})
+	}
+	server.UpdateDomain("example.com", func(d *porkbuntest.Domain) {
+		d.Labels = []porkbuntest.Label{{ID: "1", Title: "prod", Color: "#00ff00"}}
+	})
+
+	domains, err := client.ListAllDomains(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != total {
+		t.Fatalf("expected %d domains, got %d", total, len(domains))
+	}
+	if n := server.RequestCount("domain/listAll"); n != 3 {
+		t.Errorf("expected 3 pages, got %d domain/listAll requests", n)
+	}
+
+	last := domains[len(domains)-1]
+	if last.Domain != "example.com" {
+		t.Fatalf("expected example.com to sort last, got %s", last.Domain)
+	}
+	if want := []porkbun.DomainLabel{{ID: "1", Title: "prod", Color: "#00ff00"}}; !reflect.DeepEqual(last.Labels, want) {
+		t.Errorf("labels = %+v, want %+v", last.Labels, want)
+	}
+}
+
+func TestRecordsByNameType(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	for _, r := range []porkbun.DnsRecord{
+		{Name: "www", Type: "A", Content: "192.0.2.1"},
+		{Name: "www", Type: "A", Content: "192.0.2.2"},
+		{Name: "www", Type: "AAAA", Content: "2001:db8::1"},
+		{Type: "A", Content: "192.0.2.3"},
+	} {
+		if _, err := client.CreateRecord(ctx, "example.com", r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A fresh client has no zone cached and asks the endpoint.
+	cold := server.Client()
+	records, err := cold.RetrieveRecordsByNameType(ctx, "example.com", "a", "www")
+	if err != nil || len(records) != 2 {
+		t.Fatalf("RetrieveRecordsByNameType = %+v, %v", records, err)
+	}
+	if apex, _ := cold.RetrieveRecordsByNameType(ctx, "example.com", "A", ""); len(apex) != 1 || apex[0].Content != "192.0.2.3" {
+		t.Errorf("apex lookup = %+v", apex)
+	}
+	if n := server.RequestCount("dns/retrieveByNameType"); n != 2 {
+		t.Errorf("expected 2 dns/retrieveByNameType requests, got %d", n)
+	}
+
+	// A warm zone answers lookups without any request.
+	if _, err := client.RetrieveRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	server.ResetRequests()
+	if records, _ := client.RetrieveRecordsByNameType(ctx, "example.com", "A", "www.example.com"); len(records) != 2 {
+		t.Errorf("cached lookup = %+v", records)
+	}
+	if n := server.RequestCount(""); n != 0 {
+		t.Errorf("expected no requests for a cached lookup, got %d", n)
+	}
+
+	if err := client.EditRecordsByNameType(ctx, "example.com", "A", "www", porkbun.DnsRecord{Content: "198.51.100.1", TTL: 900}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteRecordsByNameType(ctx, "example.com", "AAAA", "www"); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, _ := client.RetrieveRecords(ctx, "example.com")
+	fresh, err := server.Client().RetrieveRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cached, fresh) {
+		t.Errorf("cached zone diverged from the API:\ncached: %+v\nfresh:  %+v", cached, fresh)
+	}
+	if len(fresh) != 3 || fresh[0].Content != "198.51.100.1" || fresh[1].TTL != 900 {
+		t.Errorf("unexpected zone after edit and delete: %+v", fresh)
+	}
+}
+
+func TestEditRecordsByNameTypeClearsNotes(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if _, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.1", Notes: "TICKET-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.EditRecordsByNameType(ctx, "example.com", "A", "www", porkbun.DnsRecord{Content: "192.0.2.2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	d, _ := server.Domain("example.com")
+	if len(d.Records) != 1 || d.Records[0].Content != "192.0.2.2" || d.Records[0].Notes != "" {
+		t.Errorf("expected the notes to be cleared, got %+v", d.Records)
+	}
+	cached, _ := client.RetrieveRecords(ctx, "example.com")
+	if len(cached) != 1 || cached[0].Notes != "" {
+		t.Errorf("expected the cached notes to be cleared, got %+v", cached)
+	}
+}
+
+func TestRetrieveRecord(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	id, err := client.CreateRecord(ctx, "example.com", porkbun.DnsRecord{Name: "www", Type: "A", Content: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a cached zone the zone is listed once and cached for the
+	// following lookups.
+	cold := server.Client()
+	server.ResetRequests()
+	record, err := cold.RetrieveRecord(ctx, "example.com", id)
+	if err != nil || record.Content != "192.0.2.1" || record.Name != "www.example.com" {
+		t.Fatalf("RetrieveRecord = %+v, %v", record, err)
+	}
+	if _, err := cold.RetrieveRecord(ctx, "example.com", "999"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown ID, got %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 1 || requests[0].Endpoint != "dns/retrieve" || len(requests[0].Args) != 0 {
+		t.Errorf("expected a single zone listing, got %+v", requests)
+	}
+
+	// If the zone cannot be listed, the single record is fetched instead.
+	fallback := server.Client()
+	server.ResetRequests()
+	server.InjectFault(porkbuntest.ErrorFault("dns/retrieve", 1, "Zone too large."))
+	if record, err := fallback.RetrieveRecord(ctx, "example.com", id); err != nil || record.ID != id {
+		t.Fatalf("fallback RetrieveRecord = %+v, %v", record, err)
+	}
+	if requests := server.Requests(); len(requests) != 2 || len(requests[1].Args) != 1 {
+		t.Errorf("expected a zone listing followed by a single-record request, got %+v", requests)
+	}
+
+	// A warm zone answers without any request.
+	if _, err := client.RetrieveRecords(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	server.ResetRequests()
+	if record, err := client.RetrieveRecord(ctx, "example.com", id); err != nil || record.ID != id {
+		t.Errorf("cached RetrieveRecord = %+v, %v", record, err)
+	}
+	if _, err := client.RetrieveRecord(ctx, "example.com", "999"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown cached ID, got %v", err)
+	}
+	if n := server.RequestCount(""); n != 0 {
+		t.Errorf("expected no requests for a cached lookup, got %d", n)
+	}
+}
+
+func TestUrlForwarding(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.UpdateDomain("example.com", func(d *porkbuntest.Domain) {
+		d.UrlForwards = []porkbuntest.UrlForward{
+			{ID: "1", Subdomain: "old", Location: "https://example.net", Type: "temporary", IncludePath: "no", Wildcard: "no"},
+		}
+	})
+
+	forward := porkbun.UrlForward{Subdomain: "www", Location: "https://example.net", Type: "permanent", IncludePath: true}
+	id, err := client.AddUrlForward(ctx, "example.com", forward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id == "" || id == "1" {
+		t.Fatalf("AddUrlForward returned ID %q", id)
+	}
+	if reqs := server.Requests(); reqs[1].Body["includePath"] != "yes" || reqs[1].Body["wildcard"] != "no" {
+		t.Errorf("unexpected addUrlForward payload %v", reqs[1].Body)
+	}
+
+	forwards, err := client.GetUrlForwarding(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forwards) != 2 || forwards[1].ID != id || !bool(forwards[1].IncludePath) || bool(forwards[1].Wildcard) {
+		t.Errorf("unexpected forwards after add: %+v", forwards)
+	}
+
+	if err := client.DeleteUrlForward(ctx, "example.com", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteUrlForward(ctx, "example.com", "1"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted forward, got %v", err)
+	}
+	cached, _ := client.GetUrlForwarding(ctx, "example.com")
+	fresh, _ := server.Client().GetUrlForwarding(ctx, "example.com")
+	if !reflect.DeepEqual(cached, fresh) || len(cached) != 1 {
+		t.Errorf("cached forwards = %+v, API has %+v", cached, fresh)
+	}
+	// One listing before and one after the add, one for the fresh client.
+	if n := server.RequestCount("domain/getUrlForwarding"); n != 3 {
+		t.Errorf("expected 3 domain/getUrlForwarding requests, got %d", n)
+	}
+}
+
+func TestWaitForDomain(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.DelayListing("example.com", 2)
+
+	listing, err := client.WaitForDomain(ctx, "Example.com")
+	if err != nil {
+		t.Fatalf("WaitForDomain: %s", err)
+	}
+	if listing.Domain != "example.com" {
+		t.Errorf("unexpected listing %+v", listing)
+	}
+	if n := server.RequestCount("domain/listAll"); n != 3 {
+		t.Errorf("expected 3 domain/listAll requests, got %d", n)
+	}
+
+	server.DelayListing("example.com", 1000)
+	if _, err := client.WaitForDomain(ctx, "example.com"); !errors.Is(err, porkbun.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Sentinel classifications for API failures. Use errors.Is to test an error
+// returned by the client against them.
+var (
+	ErrNotFound          = errors.New("porkbun: not found")
+	ErrAuthFailed        = errors.New("porkbun: authentication failed")
+	ErrAPIAccessDisabled = errors.New("porkbun: API access not enabled for domain")
+	ErrRateLimited       = errors.New("porkbun: rate limited")
+	ErrValidation        = errors.New("porkbun: validation failed")
+	ErrDuplicateRecord   = errors.New("porkbun: record already exists")
+)
+
+// APIError is returned for every response that Porkbun answers with a non-200
+// status code or with status "ERROR".
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	Endpoint   string
+	Domain     string
+
+	kind error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("Porkbun API error")
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " on %s", e.Endpoint)
+	}
+	if e.Domain != "" {
+		fmt.Fprintf(&b, " for %s", e.Domain)
+	}
+	if e.StatusCode != http.StatusOK {
+		fmt.Fprintf(&b, " (HTTP %d)", e.StatusCode)
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	return b.String()
+}
+
+// Is reports whether the error belongs to one of the sentinel classifications.
+func (e *APIError) Is(target error) bool {
+	return e.kind != nil && target == e.kind
+}
+
+// Kind returns the sentinel classification of the error, or nil if the
+// failure could not be classified.
+func (e *APIError) Kind() error {
+	return e.kind
+}
+
+func (c *Client) newAPIError(req *http.Request, statusCode int, status, message string) *APIError {
+	endpoint, domain := c.splitEndpoint(req.URL)
+	return &APIError{
+		StatusCode: statusCode,
+		Status:     status,
+		Message:    message,
+		Endpoint:   endpoint,
+		Domain:     domain,
+		kind:       classifyAPIError(statusCode, status, message),
+	}
+}
+
+// splitEndpoint turns a request URL such as <BaseURL>/dns/delete/example.com/123
+// into the endpoint ("dns/delete") and the domain it operates on ("example.com").
+func (c *Client) splitEndpoint(u *url.URL) (string, string) {
+	p := u.Path
+	if base, err := url.Parse(c.BaseURL); err == nil {
+		p = strings.TrimPrefix(p, strings.TrimSuffix(base.Path, "/"))
+	}
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1, 2:
+		return strings.Join(parts, "/"), ""
+	default:
+		return parts[0] + "/" + parts[1], parts[2]
+	}
+}
+
+// isDuplicateRecordMessage reports whether a lower-cased Porkbun error message
+// means that a DNS record could not be created. Porkbun answers this way when
+// an identical record already exists.
+func isDuplicateRecordMessage(message string) bool {
+	return strings.Contains(message, "unable to create the dns record")
+}
+
+// isRateLimitMessage reports whether a lower-cased Porkbun error message means
+// that the request was rejected because of rate limiting.
+func isRateLimitMessage(message string) bool {
+	return strings.Contains(message, "rate limit") || strings.Contains(message, "too many requests")
+}
+
+// classifyAPIError maps a failed response to one of the sentinel errors.
+// status is the "status" field of a Porkbun JSON body, if there was one. A
+// failure is only classified as ErrNotFound if Porkbun itself says so: a bare
+// 404 may as well come from a wrong base URL or a proxy, and treating it as a
+// missing object would drop resources from the state.
+func classifyAPIError(statusCode int, status, message string) error {
+	msg := strings.ToLower(message)
+
+	switch {
+	case statusCode == http.StatusTooManyRequests || isRateLimitMessage(msg):
+		return ErrRateLimited
+	case strings.Contains(msg, "api access") || strings.Contains(msg, "opted in"):
+		return ErrAPIAccessDisabled
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden ||
+		strings.Contains(msg, "api key") || strings.Contains(msg, "authentication"):
+		return ErrAuthFailed
+	case status == "ERROR" && (strings.Contains(msg, "not found") ||
+		strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "could not find") ||
+		strings.Contains(msg, "invalid record id")):
+		return ErrNotFound
+	case isDuplicateRecordMessage(msg):
+		return ErrDuplicateRecord
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity ||
+		strings.Contains(msg, "invalid") ||
+		strings.Contains(msg, "required") ||
+		strings.Contains(msg, "must be"):
+		return ErrValidation
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		message string
+		want    error
+	}{
+		{http.StatusOK, "ERROR", "Could not find glue record.", ErrNotFound},
+		{http.StatusOK, "ERROR", "Domain not found.", ErrNotFound},
+		{http.StatusOK, "ERROR", "Invalid record ID.", ErrNotFound},
+		{http.StatusNotFound, "", "", nil},
+		{http.StatusNotFound, "", "<html><body>404 Not Found</body></html>", nil},
+		{http.StatusOK, "ERROR", "Invalid API key. (002)", ErrAuthFailed},
+		{http.StatusForbidden, "", "", ErrAuthFailed},
+		{http.StatusOK, "ERROR", "Domain is not opted in to API access.", ErrAPIAccessDisabled},
+		{http.StatusOK, "ERROR", "You have exceeded your rate limit.", ErrRateLimited},
+		{http.StatusTooManyRequests, "", "", ErrRateLimited},
+		{http.StatusBadRequest, "ERROR", "Create error: We were unable to create the DNS record.", ErrDuplicateRecord},
+		{http.StatusOK, "ERROR", "Invalid type.", ErrValidation},
+		{http.StatusBadRequest, "ERROR", "Something went wrong.", ErrValidation},
+		{http.StatusOK, "ERROR", "Something went wrong.", nil},
+	}
+
+	for _, tt := range tests {
+		if got := classifyAPIError(tt.status, tt.body, tt.message); got != tt.want {
+			t.Errorf("classifyAPIError(%d, %q, %q) = %v, want %v", tt.status, tt.body, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestBare404IsNotNotFound(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<html><body>404 Not Found</body></html>`))
+	})
+
+	err := client.DeleteRecord(context.Background(), "example.com", "1")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("a 404 without a Porkbun error body must not be ErrNotFound, got %v", err)
+	}
+}
+
+func TestAPIErrorFields(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"ERROR","message":"Could not find glue record."}`))
+	})
+
+	err := client.DeleteGlueRecord(context.Background(), "example.com", "ns1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Status != "ERROR" {
+		t.Errorf("unexpected status: %d %q", apiErr.StatusCode, apiErr.Status)
+	}
+	if apiErr.Endpoint != "domain/deleteGlue" || apiErr.Domain != "example.com" {
+		t.Errorf("unexpected endpoint/domain: %q %q", apiErr.Endpoint, apiErr.Domain)
+	}
+	if apiErr.Message != "Could not find glue record." {
+		t.Errorf("unexpected message: %q", apiErr.Message)
+	}
+}
@@ -0,0 +1,43 @@
+package porkbun
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem used for all client logs. Its level can
+// be tuned independently via TF_LOG_PROVIDER_PORKBUN_API.
+const logSubsystem = "api"
+
+// privateKeyPattern matches PEM private keys, e.g. in ssl/retrieve responses.
+var privateKeyPattern = regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+// logContext returns ctx with the client's log subsystem attached, tagged
+// with the endpoint and domain of the request, and with both API keys and any
+// private key masked wherever they would appear in a log message or field.
+func (c *Client) logContext(ctx context.Context, u *url.URL) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_PORKBUN", logSubsystem))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "apikey", "secretapikey")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, logSubsystem, privateKeyPattern)
+
+	var secrets []string
+	for _, secret := range []string{c.apiKey, c.secretKey} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, secrets...)
+	}
+
+	endpoint, domain := c.splitEndpoint(u)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "endpoint", endpoint)
+	if domain != "" {
+		ctx = tflog.SubsystemSetField(ctx, logSubsystem, "domain", domain)
+	}
+	return ctx
+}
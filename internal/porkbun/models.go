@@ -2,20 +2,31 @@ package porkbun
 
 import (
 	"net/http"
-	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 type Client struct {
-	apiKey          string
-	secretKey       string
-	BaseURL         string
-	HTTPClient      *http.Client
-	mu              sync.Mutex
-	recordsCache    map[string][]DnsRecord
-	pricingCache    map[string]TldPricing
-	glueRecordCache map[string]map[string][]string
-	dnssecCache     map[string][]DnssecRecord
-	domainListCache []DomainListing
+	apiKey       string
+	secretKey    string
+	BaseURL      string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	MaxRetryWait time.Duration
+	// CacheTTL bounds how long read results are reused. Zero keeps them for
+	// the lifetime of the client.
+	CacheTTL        time.Duration
+	retryBaseDelay  time.Duration
+	limiter         *tokenBucket
+	inFlight        chan struct{}
+	flight          singleflight.Group
+	recordsCache    *cache[[]DnsRecord]
+	pricingCache    *cache[map[string]TldPricing]
+	glueRecordCache *cache[map[string][]string]
+	dnssecCache     *cache[[]DnssecRecord]
+	domainListCache *cache[[]DomainListing]
+	urlForwardCache *cache[[]UrlForward]
 }
 
 type Auth struct {
@@ -52,8 +63,8 @@ type GlueRecordIPs struct {
 }
 
 type GetGlueRecordsResponse struct {
-	Status string          `json:"status"`
-	Hosts  [][]interface{} `json:"hosts"`
+	Status string     `json:"status"`
+	Hosts  []GlueHost `json:"hosts"`
 }
 
 type DnssecRecord struct {
@@ -69,27 +80,101 @@ type GetDnssecResponse struct {
 }
 
 type DnsRecord struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Content string `json:"content"`
-	TTL     string `json:"ttl"`
-	Prio    string `json:"prio,omitempty"`
-	Notes   string `json:"notes,omitempty"`
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Content string  `json:"content"`
+	TTL     FlexInt `json:"ttl,omitempty"`
+	Prio    FlexInt `json:"prio,omitempty"`
+	Notes   string  `json:"notes,omitempty"`
+}
+
+type RetrieveRecordsResponse struct {
+	Status  string      `json:"status"`
+	Records []DnsRecord `json:"records"`
 }
 
 type DomainListing struct {
-	Domain       string      `json:"domain"`
-	Status       string      `json:"status"`
-	Tld          string      `json:"tld"`
-	CreateDate   string      `json:"createDate"`
-	ExpireDate   string      `json:"expireDate"`
-	SecurityLock interface{} `json:"securityLock"`
-	WhoisPrivacy interface{} `json:"whoisPrivacy"`
-	AutoRenew    interface{} `json:"autoRenew"`
+	Domain       string        `json:"domain"`
+	Status       string        `json:"status"`
+	Tld          string        `json:"tld"`
+	CreateDate   Date          `json:"createDate"`
+	ExpireDate   Date          `json:"expireDate"`
+	SecurityLock FlexBool      `json:"securityLock"`
+	WhoisPrivacy FlexBool      `json:"whoisPrivacy"`
+	AutoRenew    FlexBool      `json:"autoRenew"`
+	Labels       []DomainLabel `json:"labels"`
+}
+
+type DomainLabel struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Color string `json:"color"`
 }
 
 type ListAllResponse struct {
 	Status  string          `json:"status"`
 	Domains []DomainListing `json:"domains"`
 }
+
+type UrlForward struct {
+	ID          string   `json:"id"`
+	Subdomain   string   `json:"subdomain"`
+	Location    string   `json:"location"`
+	Type        string   `json:"type"`
+	IncludePath FlexBool `json:"includePath"`
+	Wildcard    FlexBool `json:"wildcard"`
+}
+
+type GetUrlForwardingResponse struct {
+	Status   string       `json:"status"`
+	Forwards []UrlForward `json:"forwards"`
+}
+
+type SslBundle struct {
+	CertificateChain string `json:"certificatechain"`
+	PrivateKey       string `json:"privatekey"`
+	PublicKey        string `json:"publickey"`
+}
+
+type DomainPrice struct {
+	Type         string `json:"type"`
+	Price        string `json:"price"`
+	RegularPrice string `json:"regularPrice"`
+}
+
+type DomainAvailability struct {
+	Avail          FlexBool `json:"avail"`
+	Type           string   `json:"type"`
+	Price          string   `json:"price"`
+	FirstYearPromo FlexBool `json:"firstYearPromo"`
+	RegularPrice   string   `json:"regularPrice"`
+	Premium        FlexBool `json:"premium"`
+	Additional     struct {
+		Renewal  DomainPrice `json:"renewal"`
+		Transfer DomainPrice `json:"transfer"`
+	} `json:"additional"`
+}
+
+// CheckDomainLimits describes the rate limit of domain/checkDomain: Used of
+// Limit checks within TTL seconds.
+type CheckDomainLimits struct {
+	TTL             FlexInt `json:"TTL"`
+	Limit           FlexInt `json:"limit"`
+	Used            FlexInt `json:"used"`
+	NaturalLanguage string  `json:"naturalLanguage"`
+}
+
+type CheckDomainResponse struct {
+	Status   string             `json:"status"`
+	Response DomainAvailability `json:"response"`
+	Limits   CheckDomainLimits  `json:"limits"`
+}
+
+type CreateDomainResponse struct {
+	Status  string  `json:"status"`
+	Domain  string  `json:"domain"`
+	Cost    FlexInt `json:"cost"`
+	OrderID FlexInt `json:"orderId"`
+	Balance FlexInt `json:"balance"`
+}
@@ -0,0 +1,110 @@
+package porkbun
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenBucket is a simple token bucket shared by all requests of a Client.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// the token becomes valid.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token that was reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// SetRateLimit limits the client to requestsPerSecond requests on average,
+// allowing short bursts of up to one second's worth of requests. A value of
+// zero or less removes the limit. It must be called before the client is used.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newTokenBucket(requestsPerSecond)
+}
+
+// SetMaxConcurrentRequests caps the number of requests in flight at the same
+// time. A value of zero or less removes the cap. It must be called before the
+// client is used.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.inFlight = nil
+		return
+	}
+	c.inFlight = make(chan struct{}, n)
+}
+
+// acquire blocks until the request may be sent under the configured rate
+// limit and concurrency cap. The returned function releases the slot.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	release := func() {}
+
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+			release = func() { <-c.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if c.limiter != nil {
+		if wait := c.limiter.reserve(); wait > 0 {
+			if err := sleepContext(ctx, wait); err != nil {
+				c.limiter.cancel()
+				release()
+				return nil, err
+			}
+		}
+	}
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Throttled Porkbun API request", map[string]interface{}{
+			"wait_ms":   waited.Milliseconds(),
+			"in_flight": len(c.inFlight),
+		})
+	}
+
+	return release, nil
+}
@@ -0,0 +1,77 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	client, _ := newTestClient(t, pingHandler)
+	client.SetRateLimit(20)
+
+	// The first 20 requests use the initial burst, the next 10 have to wait
+	// for roughly half a second worth of tokens.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, err := client.Ping(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("rate limit not enforced, 30 requests took %s", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var current, peak int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		pingHandler(w, r)
+	})
+	client.SetMaxConcurrentRequests(3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Ping(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent requests, saw %d", peak)
+	}
+}
+
+func TestAcquireHonoursContext(t *testing.T) {
+	client := NewClient("pk1_test", "sk1_test")
+	client.SetMaxConcurrentRequests(1)
+
+	release, err := client.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to fail once the context expires")
+	}
+}
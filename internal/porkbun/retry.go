@@ -0,0 +1,181 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultMaxRetryWait   = 30 * time.Second
+	defaultRetryBaseDelay = time.Second
+)
+
+// retryableError marks a failure that may succeed when the request is sent
+// again. mayHaveApplied is true when the server could have acted on the
+// request before failing, which makes replaying non-idempotent calls unsafe.
+type retryableError struct {
+	err            error
+	retryAfter     time.Duration
+	mayHaveApplied bool
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// classifyTransportError decides whether an error returned by the HTTP client
+// is worth retrying. Errors raised while dialing are known to have happened
+// before anything was written to the wire. A cancelled or expired request
+// context is final, whereas a per-request timeout of the HTTP client is not.
+func classifyTransportError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return &retryableError{err: err}
+	}
+
+	return &retryableError{err: err, mayHaveApplied: true}
+}
+
+// classifyResponse marks API errors that indicate a transient condition as
+// retryable. Rate limited requests were rejected before being processed; a
+// 5xx response gives no such guarantee.
+func classifyResponse(resp *http.Response, apiErr *APIError) error {
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+	switch {
+	case errors.Is(apiErr, ErrRateLimited):
+		return &retryableError{err: apiErr, retryAfter: retryAfter}
+	case apiErr.StatusCode >= 500:
+		return &retryableError{err: apiErr, retryAfter: retryAfter, mayHaveApplied: true}
+	default:
+		return apiErr
+	}
+}
+
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// backoff returns the delay before the given retry attempt (starting at 0).
+// A server supplied Retry-After takes precedence over the jittered
+// exponential delay; both are capped at MaxRetryWait.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	maxWait := c.MaxRetryWait
+	if maxWait <= 0 {
+		maxWait = defaultMaxRetryWait
+	}
+
+	wait := retryAfter
+	if wait <= 0 {
+		base := c.retryBaseDelay
+		if base <= 0 {
+			base = defaultRetryBaseDelay
+		}
+		wait = base << attempt
+		if wait <= 0 || wait > maxWait {
+			wait = maxWait
+		}
+		half := wait / 2
+		wait = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+
+	if wait > maxWait {
+		wait = maxWait
+	}
+	return wait
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doWithRetry sends req until it succeeds, fails with a non-retryable error or
+// MaxRetries is exhausted. Non-idempotent requests are only replayed when the
+// previous attempt provably did not reach the API.
+func (c *Client) doWithRetry(req *http.Request, v interface{}, idempotent bool) error {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := c.doOnce(attemptReq, v)
+		if err == nil {
+			return nil
+		}
+
+		var retryErr *retryableError
+		if !errors.As(err, &retryErr) {
+			return err
+		}
+		if attempt >= c.MaxRetries || (!idempotent && retryErr.mayHaveApplied) {
+			return retryErr.err
+		}
+
+		wait := c.backoff(attempt, retryErr.retryAfter)
+		tflog.SubsystemDebug(req.Context(), logSubsystem, "Retrying Porkbun API request", map[string]interface{}{
+			"attempt": attempt + 1,
+			"wait_ms": wait.Milliseconds(),
+			"error":   retryErr.err.Error(),
+		})
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package porkbun
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = server.URL
+	client.retryBaseDelay = time.Millisecond
+	client.MaxRetryWait = 50 * time.Millisecond
+	return client, server
+}
+
+func TestRetryOnTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway} {
+		var calls int32
+		client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(status)
+				return
+			}
+			w.Write([]byte(`{"status":"SUCCESS","yourIp":"192.0.2.1"}`))
+		})
+
+		ip, err := client.Ping(context.Background())
+		if err != nil {
+			t.Fatalf("status %d: unexpected error: %s", status, err)
+		}
+		if ip != "192.0.2.1" {
+			t.Errorf("status %d: got ip %q", status, ip)
+		}
+		if calls != 3 {
+			t.Errorf("status %d: expected 3 calls, got %d", status, calls)
+		}
+	}
+}
+
+func TestRetryOnRateLimitMessage(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Write([]byte(`{"status":"ERROR","message":"You have exceeded your rate limit. Please wait."}`))
+			return
+		}
+		w.Write([]byte(`{"status":"SUCCESS","id":42}`))
+	})
+
+	id, err := client.CreateRecord(context.Background(), "example.com", DnsRecord{Type: "A", Content: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != "42" {
+		t.Errorf("got id %q, want 42", id)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || !strings.Contains(string(body), `"secretapikey":"sk1_test"`) {
+			t.Errorf("missing request body on replay: %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"status":"SUCCESS"}`))
+	})
+
+	if err := client.UpdateNameservers(context.Background(), "example.com", []string{"ns1.example.net"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestNoRetryOnFatalError(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"status":"ERROR","message":"Invalid API key."}`))
+	})
+
+	_, err := client.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Invalid API key.") {
+		t.Fatalf("expected API error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	client.MaxRetries = 2
+
+	_, err := client.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 500") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestCreateRecordNotReplayedAfterServerError(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if _, err := client.CreateRecord(context.Background(), "example.com", DnsRecord{Type: "A", Content: "192.0.2.1"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected CreateRecord not to be replayed, got %d calls", calls)
+	}
+}
+
+func TestCreateRecordReplayedAfterDialError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = "http://" + addr
+	client.retryBaseDelay = time.Millisecond
+	client.MaxRetryWait = 50 * time.Millisecond
+
+	var attempts int32
+	client.HTTPClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			return http.DefaultTransport.RoundTrip(r)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+			Header:     http.Header{},
+			Request:    r,
+		}, nil
+	})
+
+	// The second attempt reaches the fake transport, which answers with an
+	// empty body; reaching it at all proves the replay happened.
+	client.CreateRecord(context.Background(), "example.com", DnsRecord{Type: "A", Content: "192.0.2.1"})
+	if attempts != 2 {
+		t.Errorf("expected CreateRecord to be replayed after a dial error, got %d attempts", attempts)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	var first, second time.Time
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`{"status":"SUCCESS","yourIp":"192.0.2.1"}`))
+	})
+	client.MaxRetryWait = 2 * time.Second
+
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if waited := second.Sub(first); waited < 900*time.Millisecond {
+		t.Errorf("expected to wait for Retry-After, waited %s", waited)
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.retryBaseDelay = time.Hour
+	client.MaxRetryWait = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := client.newAuthenticatedRequest(ctx, "POST", client.BaseURL+"/ping", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := client.do(req, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry loop ignored context cancellation, took %s", elapsed)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	client := NewClient("pk1_test", "sk1_test")
+	client.MaxRetryWait = 5 * time.Second
+
+	for attempt := 0; attempt < 20; attempt++ {
+		if wait := client.backoff(attempt, 0); wait <= 0 || wait > client.MaxRetryWait {
+			t.Errorf("attempt %d: backoff %s out of range", attempt, wait)
+		}
+	}
+	if wait := client.backoff(0, time.Minute); wait != client.MaxRetryWait {
+		t.Errorf("Retry-After should be capped at MaxRetryWait, got %s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":        0,
+		"3":       3 * time.Second,
+		"-1":      0,
+		"garbage": 0,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got <= 0 || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %s", date, got)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
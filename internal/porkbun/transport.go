@@ -0,0 +1,68 @@
+package porkbun
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultRequestTimeout = 60 * time.Second
+
+// TransportOptions configures the HTTP client used to talk to the API.
+type TransportOptions struct {
+	// Timeout bounds a single HTTP request, including reading the body.
+	Timeout time.Duration
+	// ProxyURL routes all requests through the given proxy. When empty the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables apply.
+	ProxyURL string
+	// CABundlePEM holds additional PEM encoded CA certificates that are
+	// trusted on top of the system pool.
+	CABundlePEM []byte
+	// InsecureSkipVerify disables TLS certificate verification. Only meant
+	// for lab setups.
+	InsecureSkipVerify bool
+}
+
+// NewHTTPClient builds an *http.Client from opts, suitable for Client.HTTPClient.
+func NewHTTPClient(opts TransportOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(opts.CABundlePEM) > 0 || opts.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: opts.InsecureSkipVerify,
+		}
+		if len(opts.CABundlePEM) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(opts.CABundlePEM) {
+				return nil, fmt.Errorf("CA bundle does not contain any valid PEM certificates")
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func pingHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"status":"SUCCESS","yourIp":"192.0.2.1"}`))
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(pingHandler))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = server.URL
+	client.MaxRetries = 0
+
+	if _, err := client.Ping(context.Background()); err == nil {
+		t.Fatal("expected TLS verification to fail without CA bundle")
+	}
+
+	httpClient, err := NewHTTPClient(TransportOptions{CABundlePEM: caPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.HTTPClient = httpClient
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("expected CA bundle to be trusted: %s", err)
+	}
+
+	if _, err := NewHTTPClient(TransportOptions{CABundlePEM: []byte("not a certificate")}); err == nil {
+		t.Error("expected error for invalid CA bundle")
+	}
+}
+
+func TestNewHTTPClientInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(pingHandler))
+	defer server.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = server.URL
+	client.HTTPClient = httpClient
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		pingHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = "http://api.porkbun.invalid/api/json/v3"
+	client.HTTPClient = httpClient
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if proxiedHost != "api.porkbun.invalid" {
+		t.Errorf("request was not sent through the proxy, got host %q", proxiedHost)
+	}
+
+	if _, err := NewHTTPClient(TransportOptions{ProxyURL: "::not a url"}); err == nil {
+		t.Error("expected error for invalid proxy URL")
+	}
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		pingHandler(w, r)
+	}))
+	defer server.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("pk1_test", "sk1_test")
+	client.BaseURL = server.URL
+	client.HTTPClient = httpClient
+	client.MaxRetries = 0
+	if _, err := client.Ping(context.Background()); err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
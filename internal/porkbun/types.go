@@ -0,0 +1,184 @@
+package porkbun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The Porkbun API is loose with types: the same field can come back as a
+// JSON bool, a number or a string depending on the endpoint. The types in
+// this file accept every representation seen so far and fail loudly on
+// anything else instead of silently dropping data.
+
+// FlexBool is a boolean that also decodes from 1/0 and "1", "0", "true",
+// "false", "yes", "no" and "". null decodes to false.
+type FlexBool bool
+
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %s: %w", data, err)
+	}
+
+	switch strings.ToLower(raw) {
+	case "true", "1", "yes":
+		*b = true
+		return nil
+	case "false", "0", "no", "", "null":
+		*b = false
+		return nil
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil && (f == 0 || f == 1) {
+		*b = f == 1
+		return nil
+	}
+	return fmt.Errorf("invalid boolean %s", data)
+}
+
+func (b FlexBool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(b))
+}
+
+// FlexInt is an integer that also decodes from a string such as "600". null
+// and "" decode to 0. It encodes as a decimal string, which is what the API
+// expects in request bodies.
+type FlexInt int64
+
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "null" {
+		*i = 0
+		return nil
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		*i = FlexInt(n)
+		return nil
+	}
+	// Some endpoints send integral values as JSON floats, e.g. 600.0.
+	if f, err := strconv.ParseFloat(raw, 64); err == nil && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		*i = FlexInt(f)
+		return nil
+	}
+	return fmt.Errorf("invalid integer %s", data)
+}
+
+func (i FlexInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+func (i FlexInt) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// DateLayout is the layout of timestamps returned by the API. They are in UTC.
+const DateLayout = "2006-01-02 15:04:05"
+
+var dateLayouts = []string{DateLayout, time.RFC3339, "2006-01-02"}
+
+// Date is a timestamp in one of the layouts the API uses. null and "" decode
+// to the zero Date.
+type Date struct {
+	time.Time
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid date %s: %w", data, err)
+	}
+	if raw == "" || raw == "0000-00-00 00:00:00" {
+		*d = Date{}
+		return nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, raw, time.UTC); err == nil {
+			*d = Date{t.UTC()}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date %q", raw)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// String formats d in DateLayout, or returns "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateLayout)
+}
+
+// GlueHost is one entry of domain/getGlue. The API encodes it as a tuple:
+//
+//	["ns1.example.com", {"v4": ["192.0.2.1"], "v6": ["2001:db8::1"]}]
+type GlueHost struct {
+	Host string
+	V4   []string
+	V6   []string
+}
+
+func (g *GlueHost) UnmarshalJSON(data []byte) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return fmt.Errorf("invalid glue host %s: %w", data, err)
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("invalid glue host %s: expected [host, addresses], got %d elements", data, len(tuple))
+	}
+
+	var host string
+	if err := json.Unmarshal(tuple[0], &host); err != nil || host == "" {
+		return fmt.Errorf("invalid glue host name %s", tuple[0])
+	}
+
+	var ips GlueRecordIPs
+	if err := json.Unmarshal(tuple[1], &ips); err != nil {
+		return fmt.Errorf("invalid addresses for glue host %s: %w", host, err)
+	}
+
+	*g = GlueHost{Host: host, V4: ips.V4, V6: ips.V6}
+	return nil
+}
+
+func (g GlueHost) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{g.Host, GlueRecordIPs{V4: g.V4, V6: g.V6}})
+}
+
+// IPs returns the IPv4 addresses followed by the IPv6 addresses.
+func (g GlueHost) IPs() []string {
+	return append(append([]string(nil), g.V4...), g.V6...)
+}
+
+// unquote returns the contents of a JSON string, or the literal itself for
+// any other JSON value.
+func unquote(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	}
+	if len(data) > 0 && (data[0] == '{' || data[0] == '[') {
+		return "", fmt.Errorf("expected a scalar value")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,243 @@
+package porkbun
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlexBool(t *testing.T) {
+	tests := map[string]struct {
+		want    FlexBool
+		wantErr bool
+	}{
+		`true`:    {want: true},
+		`false`:   {want: false},
+		`1`:       {want: true},
+		`0`:       {want: false},
+		`1.0`:     {want: true},
+		`"1"`:     {want: true},
+		`"0"`:     {want: false},
+		`"true"`:  {want: true},
+		`"FALSE"`: {want: false},
+		`"yes"`:   {want: true},
+		`"no"`:    {want: false},
+		`""`:      {want: false},
+		`null`:    {want: false},
+		`2`:       {wantErr: true},
+		`"maybe"`: {wantErr: true},
+		`[]`:      {wantErr: true},
+		`{}`:      {wantErr: true},
+	}
+
+	for input, tt := range tests {
+		t.Run(input, func(t *testing.T) {
+			var got FlexBool
+			err := json.Unmarshal([]byte(input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexInt(t *testing.T) {
+	tests := map[string]struct {
+		want    FlexInt
+		wantErr bool
+	}{
+		`600`:     {want: 600},
+		`"600"`:   {want: 600},
+		`" 10 "`:  {want: 10},
+		`600.0`:   {want: 600},
+		`-1`:      {want: -1},
+		`""`:      {want: 0},
+		`null`:    {want: 0},
+		`600.5`:   {wantErr: true},
+		`"ten"`:   {wantErr: true},
+		`true`:    {wantErr: true},
+		`["600"]`: {wantErr: true},
+	}
+
+	for input, tt := range tests {
+		t.Run(input, func(t *testing.T) {
+			var got FlexInt
+			err := json.Unmarshal([]byte(input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	if b, _ := json.Marshal(FlexInt(600)); string(b) != `"600"` {
+		t.Errorf("FlexInt must encode as a string, got %s", b)
+	}
+}
+
+func TestDate(t *testing.T) {
+	want := time.Date(2023, 8, 20, 17, 52, 51, 0, time.UTC)
+	tests := map[string]struct {
+		want    time.Time
+		wantErr bool
+	}{
+		`"2023-08-20 17:52:51"`:       {want: want},
+		`"2023-08-20T17:52:51Z"`:      {want: want},
+		`"2023-08-20T19:52:51+02:00"`: {want: want},
+		`"2023-08-20"`:                {want: time.Date(2023, 8, 20, 0, 0, 0, 0, time.UTC)},
+		`""`:                          {},
+		`null`:                        {},
+		`"0000-00-00 00:00:00"`:       {},
+		`"20.08.2023"`:                {wantErr: true},
+		`1692553971`:                  {wantErr: true},
+	}
+
+	for input, tt := range tests {
+		t.Run(input, func(t *testing.T) {
+			var got Date
+			err := json.Unmarshal([]byte(input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Fatalf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	if got := (Date{want}).String(); got != "2023-08-20 17:52:51" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestGlueHost(t *testing.T) {
+	var got GlueHost
+	if err := json.Unmarshal([]byte(`["ns1.example.com",{"v6":["2001:db8::1"],"v4":["192.0.2.1"]}]`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Host != "ns1.example.com" || !reflect.DeepEqual(got.IPs(), []string{"192.0.2.1", "2001:db8::1"}) {
+		t.Errorf("unexpected glue host %+v", got)
+	}
+
+	for _, input := range []string{
+		`"ns1.example.com"`,
+		`["ns1.example.com"]`,
+		`["ns1.example.com",{"v4":["192.0.2.1"]},"extra"]`,
+		`[42,{"v4":["192.0.2.1"]}]`,
+		`["ns1.example.com",["192.0.2.1"]]`,
+	} {
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
+
+func TestDecodeRecordedPayloads(t *testing.T) {
+	var listAll ListAllResponse
+	decodePayload(t, "domain-listAll.json", &listAll)
+	if d := listAll.Domains[0]; !d.SecurityLock || !d.WhoisPrivacy || d.AutoRenew || d.ExpireDate.Year() != 2023 || d.Labels[0].Title != "cool" {
+		t.Errorf("unexpected listing %+v", d)
+	}
+	if d := listAll.Domains[1]; !d.SecurityLock || d.WhoisPrivacy || !d.AutoRenew {
+		t.Errorf("unexpected listing %+v", d)
+	}
+
+	var retrieve RetrieveRecordsResponse
+	decodePayload(t, "dns-retrieve.json", &retrieve)
+	if r := retrieve.Records[1]; r.TTL != 600 || r.Prio != 0 || r.Notes != "" {
+		t.Errorf("unexpected record %+v", r)
+	}
+	if r := retrieve.Records[2]; r.TTL != 3600 || r.Prio != 10 {
+		t.Errorf("unexpected record %+v", r)
+	}
+
+	var glue GetGlueRecordsResponse
+	decodePayload(t, "domain-getGlue.json", &glue)
+	if len(glue.Hosts) != 2 || len(glue.Hosts[0].IPs()) != 3 || glue.Hosts[1].V6 != nil {
+		t.Errorf("unexpected glue hosts %+v", glue.Hosts)
+	}
+
+	var check CheckDomainResponse
+	decodePayload(t, "domain-checkDomain.json", &check)
+	if r := check.Response; !r.Avail || r.Premium || !r.FirstYearPromo || r.Price != "9.68" || r.Additional.Renewal.Price != "10.87" {
+		t.Errorf("unexpected availability %+v", r)
+	}
+	if l := check.Limits; l.TTL != 10 || l.Limit != 1 || l.Used != 1 {
+		t.Errorf("unexpected limits %+v", l)
+	}
+}
+
+// FuzzDecode feeds mutations of recorded API payloads into the response
+// types. Decoding must never panic, and whatever decodes has to survive an
+// encode/decode round trip unchanged.
+func FuzzDecode(f *testing.F) {
+	files, _ := filepath.Glob(filepath.Join("testdata", "payloads", "*.json"))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip[ListAllResponse](t, data)
+		fuzzRoundTrip[RetrieveRecordsResponse](t, data)
+		fuzzRoundTrip[GetGlueRecordsResponse](t, data)
+		fuzzRoundTrip[CheckDomainResponse](t, data)
+	})
+}
+
+func fuzzRoundTrip[T any](t *testing.T, data []byte) {
+	var first T
+	if json.Unmarshal(data, &first) != nil {
+		return
+	}
+
+	// The first decode may normalise values (e.g. "1" to true), so compare
+	// the second and third generation.
+	second := reencode(t, first)
+	if third := reencode(t, second); !reflect.DeepEqual(second, third) {
+		t.Fatalf("round trip changed the value:\n%+v\n%+v", second, third)
+	}
+}
+
+func reencode[T any](t *testing.T, v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("encoding %+v: %s", v, err)
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("decoding %s: %s", data, err)
+	}
+	return out
+}
+
+func decodePayload(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "payloads", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decoding %s: %s", name, err)
+	}
+}
@@ -0,0 +1,118 @@
+package porkbuntest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Fault makes the fake answer matching requests with a canned response
+// instead of handling them.
+type Fault struct {
+	// Endpoint restricts the fault to one endpoint such as "dns/create".
+	// An empty Endpoint matches every request.
+	Endpoint string
+	// Times is the number of requests the fault applies to. Zero means once,
+	// a negative value means forever.
+	Times int
+
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// RateLimitFault answers with HTTP 429 and a Porkbun rate limit message.
+func RateLimitFault(endpoint string, times int, retryAfterSeconds int) Fault {
+	header := http.Header{}
+	if retryAfterSeconds > 0 {
+		header.Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	}
+	return Fault{
+		Endpoint:   endpoint,
+		Times:      times,
+		StatusCode: http.StatusTooManyRequests,
+		Header:     header,
+		Body:       `{"status":"ERROR","message":"You have exceeded your rate limit. Please try again later."}`,
+	}
+}
+
+// ServerErrorFault answers with the given 5xx status code.
+func ServerErrorFault(endpoint string, times int, statusCode int) Fault {
+	return Fault{
+		Endpoint:   endpoint,
+		Times:      times,
+		StatusCode: statusCode,
+		Body:       http.StatusText(statusCode),
+	}
+}
+
+// MalformedJSONFault answers with HTTP 200 and a body that is not valid JSON.
+func MalformedJSONFault(endpoint string, times int) Fault {
+	return Fault{
+		Endpoint:   endpoint,
+		Times:      times,
+		StatusCode: http.StatusOK,
+		Body:       `{"status":"SUCCESS",`,
+	}
+}
+
+// ErrorFault answers with a Porkbun ERROR response carrying message.
+func ErrorFault(endpoint string, times int, message string) Fault {
+	return Fault{
+		Endpoint:   endpoint,
+		Times:      times,
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"status":"ERROR","message":` + strconv.Quote(message) + `}`,
+	}
+}
+
+// InjectFault registers a fault. Faults are matched in the order they were
+// injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f.Times == 0 {
+		f.Times = 1
+	}
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all pending faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching endpoint and consumes one of its
+// uses. The caller must hold s.mu.
+func (s *Server) takeFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (f *Fault) write(w http.ResponseWriter) {
+	for key, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	statusCode := f.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	w.Write([]byte(f.Body))
+}
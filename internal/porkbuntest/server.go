@@ -0,0 +1,1011 @@
+// Package porkbuntest provides an in-memory, stateful fake of the Porkbun v3
+// JSON API for use in tests. The fake speaks the same wire format as
+// api.porkbun.com, so both porkbun.Client unit tests and provider acceptance
+// tests can be pointed at it via its URL.
+package porkbuntest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+)
+
+const (
+	DefaultAPIKey    = "pk1_porkbuntest"
+	DefaultSecretKey = "sk1_porkbuntest"
+
+	// ListAllPageSize is the number of domains domain/listAll returns per
+	// page, like the real API.
+	ListAllPageSize = 1000
+
+	// CheckDomainLimit is the number of domain/checkDomain calls the fake
+	// reports as allowed per CheckDomainWindow seconds. It is not enforced.
+	CheckDomainLimit  = 10
+	CheckDomainWindow = 60
+
+	apiPathPrefix = "/api/json/v3"
+	firstRecordID = 100000001
+)
+
+// DefaultNameservers are assigned to domains added without explicit nameservers.
+var DefaultNameservers = []string{
+	"curia.porkbun.com",
+	"livia.porkbun.com",
+	"pliny.porkbun.com",
+	"salvia.porkbun.com",
+}
+
+// Record is a DNS record as stored by the fake. Name holds the fully
+// qualified name, just like the real API returns it.
+type Record struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     string `json:"ttl"`
+	Prio    string `json:"prio"`
+	Notes   string `json:"notes"`
+}
+
+// DnssecRecord is a DS record as stored by the fake.
+type DnssecRecord struct {
+	Algorithm  string `json:"algorithm"`
+	DigestType string `json:"digestType"`
+	KeyTag     string `json:"keyTag"`
+	Digest     string `json:"digest"`
+}
+
+// Label is an account label attached to a domain.
+type Label struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Color string `json:"color"`
+}
+
+// UrlForward is a URL forward as stored by the fake. IncludePath and
+// Wildcard hold "yes" or "no", like the API returns them.
+type UrlForward struct {
+	ID          string `json:"id"`
+	Subdomain   string `json:"subdomain"`
+	Location    string `json:"location"`
+	Type        string `json:"type"`
+	IncludePath string `json:"includePath"`
+	Wildcard    string `json:"wildcard"`
+}
+
+// Domain is a domain in the fake account. Glue maps the host part (e.g. "ns1")
+// to its IP addresses.
+type Domain struct {
+	Name              string
+	Status            string
+	CreateDate        string
+	ExpireDate        string
+	SecurityLock      bool
+	WhoisPrivacy      bool
+	AutoRenew         bool
+	APIAccessDisabled bool
+	Labels            []Label
+	Nameservers       []string
+	Records           []Record
+	Glue              map[string][]string
+	Dnssec            []DnssecRecord
+	UrlForwards       []UrlForward
+	Ssl               *SslBundle
+}
+
+// Request is a request received by the fake, with the credentials removed
+// from Body.
+type Request struct {
+	Endpoint string
+	Domain   string
+	Args     []string
+	Body     map[string]interface{}
+}
+
+// Server is the fake Porkbun API.
+type Server struct {
+	*httptest.Server
+
+	APIKey    string
+	SecretKey string
+
+	// OnRequest, if set, is called for every request before it is handled.
+	OnRequest func(Request)
+
+	mu        sync.Mutex
+	domains   map[string]*Domain
+	pricing   map[string]porkbun.TldPricing
+	avail     map[string]availability
+	hidden    map[string]int
+	nextID    int
+	nextOrder int
+	requests  []Request
+	faults    []*Fault
+}
+
+// NewServer starts a fake Porkbun API. It is closed automatically when the
+// test finishes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		SecretKey: DefaultSecretKey,
+		domains:   make(map[string]*Domain),
+		avail:     make(map[string]availability),
+		hidden:    make(map[string]int),
+		pricing: map[string]porkbun.TldPricing{
+			"com": {Registration: "11.08", Renewal: "11.08", Transfer: "11.08"},
+			"net": {Registration: "12.52", Renewal: "12.52", Transfer: "12.52"},
+			"org": {Registration: "10.72", Renewal: "10.72", Transfer: "10.72"},
+		},
+		nextID: firstRecordID,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a porkbun.Client authenticated against the fake.
+func (s *Server) Client() *porkbun.Client {
+	client := porkbun.NewClient(s.APIKey, s.SecretKey)
+	client.BaseURL = s.URL
+	return client
+}
+
+// AddDomain adds a domain to the fake account, filling in sensible defaults
+// for empty fields.
+func (s *Server) AddDomain(d Domain) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Status == "" {
+		d.Status = "ACTIVE"
+	}
+	if d.CreateDate == "" {
+		d.CreateDate = "2020-01-01 00:00:00"
+	}
+	if d.ExpireDate == "" {
+		d.ExpireDate = "2030-01-01 00:00:00"
+	}
+	if d.Nameservers == nil {
+		d.Nameservers = append([]string(nil), DefaultNameservers...)
+	}
+	if d.Glue == nil {
+		d.Glue = make(map[string][]string)
+	}
+	for i := range d.Records {
+		if d.Records[i].ID == "" {
+			d.Records[i].ID = s.newID()
+		}
+	}
+	for i := range d.UrlForwards {
+		if d.UrlForwards[i].ID == "" {
+			d.UrlForwards[i].ID = s.newID()
+		}
+	}
+	s.domains[d.Name] = &d
+}
+
+// Domain returns a copy of the named domain.
+func (s *Server) Domain(name string) (Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[name]
+	if !ok {
+		return Domain{}, false
+	}
+	return d.clone(), true
+}
+
+// UpdateDomain applies fn to the named domain, e.g. to simulate changes made
+// outside of Terraform.
+func (s *Server) UpdateDomain(name string, fn func(*Domain)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.domains[name]; ok {
+		fn(d)
+	}
+}
+
+// SetPricing replaces the pricing table returned by pricing/get.
+func (s *Server) SetPricing(pricing map[string]porkbun.TldPricing) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pricing = pricing
+}
+
+// DelayListing leaves the named domain out of the next n domain/listAll
+// responses while it is in the account, like Porkbun does for a while after a
+// registration. n = 0 lists it right away again.
+func (s *Server) DelayListing(name string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hidden[name] = n
+}
+
+type availability struct {
+	available    bool
+	premiumPrice string
+}
+
+// SetAvailability overrides the domain/checkDomain answer for name. A
+// non-empty premiumPrice marks the domain as premium at that price. Without
+// an override, every domain outside the account with a priced TLD is
+// available at the TLD's registration price.
+func (s *Server) SetAvailability(name string, available bool, premiumPrice string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.avail[name] = availability{available: available, premiumPrice: premiumPrice}
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns how many requests were made to the given endpoint,
+// e.g. "dns/retrieve". An empty endpoint counts all requests.
+func (s *Server) RequestCount(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, r := range s.requests {
+		if endpoint == "" || r.Endpoint == endpoint {
+			n++
+		}
+	}
+	return n
+}
+
+// ResetRequests clears the request log.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+func (s *Server) newID() string {
+	id := strconv.Itoa(s.nextID)
+	s.nextID++
+	return id
+}
+
+func (d *Domain) clone() Domain {
+	c := *d
+	c.Labels = append([]Label(nil), d.Labels...)
+	c.Nameservers = append([]string(nil), d.Nameservers...)
+	c.Records = append([]Record(nil), d.Records...)
+	c.Dnssec = append([]DnssecRecord(nil), d.Dnssec...)
+	c.UrlForwards = append([]UrlForward(nil), d.UrlForwards...)
+	if d.Ssl != nil {
+		ssl := *d.Ssl
+		c.Ssl = &ssl
+	}
+	c.Glue = make(map[string][]string, len(d.Glue))
+	for host, ips := range d.Glue {
+		c.Glue[host] = append([]string(nil), ips...)
+	}
+	return c
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Only POST requests are supported.")
+		return
+	}
+
+	body := make(map[string]interface{})
+	raw, err := io.ReadAll(r.Body)
+	if err != nil || (len(raw) > 0 && json.Unmarshal(raw, &body) != nil) {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body.")
+		return
+	}
+
+	apiKey, _ := body["apikey"].(string)
+	secretKey, _ := body["secretapikey"].(string)
+	delete(body, "apikey")
+	delete(body, "secretapikey")
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPathPrefix), "/"), "/")
+	req := Request{Body: body}
+	switch {
+	case len(parts) >= 3:
+		req.Endpoint, req.Domain, req.Args = parts[0]+"/"+parts[1], parts[2], parts[3:]
+	default:
+		req.Endpoint = strings.Join(parts, "/")
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	onRequest := s.OnRequest
+	fault := s.takeFault(req.Endpoint)
+	s.mu.Unlock()
+
+	if onRequest != nil {
+		onRequest(req)
+	}
+	if fault != nil {
+		fault.write(w)
+		return
+	}
+
+	if apiKey != s.APIKey || secretKey != s.SecretKey {
+		writeError(w, http.StatusForbidden, "Invalid API key. (002)")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.Endpoint {
+	case "ping":
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		writeJSON(w, map[string]interface{}{"status": "SUCCESS", "yourIp": host})
+	case "pricing/get":
+		writeJSON(w, map[string]interface{}{"status": "SUCCESS", "pricing": s.pricing})
+	case "domain/listAll":
+		s.listAll(w, req)
+	case "domain/checkDomain":
+		s.checkDomain(w, req)
+	case "domain/create":
+		s.createDomain(w, req)
+	default:
+		handler, ok := domainHandlers[req.Endpoint]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Invalid endpoint.")
+			return
+		}
+		d, ok := s.domains[req.Domain]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Domain not found.")
+			return
+		}
+		if d.APIAccessDisabled {
+			writeError(w, http.StatusBadRequest, "Domain is not opted in to API access.")
+			return
+		}
+		handler(s, w, d, req)
+	}
+}
+
+type domainHandler func(s *Server, w http.ResponseWriter, d *Domain, req Request)
+
+var domainHandlers = map[string]domainHandler{
+	"dns/create":              (*Server).createRecord,
+	"dns/edit":                (*Server).editRecord,
+	"dns/delete":              (*Server).deleteRecord,
+	"dns/retrieve":            (*Server).retrieveRecords,
+	"dns/retrieveByNameType":  (*Server).retrieveByNameType,
+	"dns/editByNameType":      (*Server).editByNameType,
+	"dns/deleteByNameType":    (*Server).deleteByNameType,
+	"domain/getNs":            (*Server).getNameservers,
+	"domain/updateNs":         (*Server).updateNameservers,
+	"domain/createGlue":       (*Server).createGlue,
+	"domain/updateGlue":       (*Server).updateGlue,
+	"domain/deleteGlue":       (*Server).deleteGlue,
+	"domain/getGlue":          (*Server).getGlue,
+	"dns/addDnssec":           (*Server).addDnssec,
+	"dns/getDnssec":           (*Server).getDnssec,
+	"dns/deleteDnssec":        (*Server).deleteDnssec,
+	"domain/addUrlForward":    (*Server).addUrlForward,
+	"domain/getUrlForwarding": (*Server).getUrlForwarding,
+	"domain/deleteUrlForward": (*Server).deleteUrlForward,
+	"ssl/retrieve":            (*Server).retrieveSsl,
+}
+
+func (s *Server) listAll(w http.ResponseWriter, req Request) {
+	start := 0
+	if v, ok := req.Body["start"]; ok {
+		n, err := strconv.Atoi(stringValue(v))
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid start index.")
+			return
+		}
+		start = n
+	}
+	includeLabels := stringValue(req.Body["includeLabels"]) == "yes"
+
+	names := make([]string, 0, len(s.domains))
+	for name := range s.domains {
+		if s.hidden[name] > 0 {
+			s.hidden[name]--
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	names = names[min(start, len(names)):]
+	names = names[:min(ListAllPageSize, len(names))]
+
+	// Porkbun is loose with types here: the flags come back as "1"/"0"
+	// strings or as numbers depending on the field.
+	domains := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		d := s.domains[name]
+		domain := map[string]interface{}{
+			"domain":       d.Name,
+			"status":       d.Status,
+			"tld":          d.Name[strings.LastIndex(d.Name, ".")+1:],
+			"createDate":   d.CreateDate,
+			"expireDate":   d.ExpireDate,
+			"securityLock": boolString(d.SecurityLock),
+			"whoisPrivacy": boolString(d.WhoisPrivacy),
+			"autoRenew":    boolInt(d.AutoRenew),
+			"notLocal":     0,
+		}
+		if includeLabels {
+			labels := d.Labels
+			if labels == nil {
+				labels = []Label{}
+			}
+			domain["labels"] = labels
+		}
+		domains = append(domains, domain)
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "domains": domains})
+}
+
+func (s *Server) checkDomain(w http.ResponseWriter, req Request) {
+	name := strings.ToLower(req.Domain)
+	prices, ok := s.pricing[name[strings.LastIndex(name, ".")+1:]]
+	if name == "" || !ok {
+		writeError(w, http.StatusBadRequest, "Invalid domain or unsupported TLD.")
+		return
+	}
+
+	_, inAccount := s.domains[name]
+	avail := availability{available: !inAccount}
+	if override, ok := s.avail[name]; ok {
+		avail = override
+	}
+	price := prices.Registration
+	if avail.premiumPrice != "" {
+		price = avail.premiumPrice
+	}
+
+	used := 0
+	for _, r := range s.requests {
+		if r.Endpoint == "domain/checkDomain" {
+			used++
+		}
+	}
+
+	// Like the real API, flags are "yes"/"no" strings and the limits mix
+	// strings and numbers.
+	writeJSON(w, map[string]interface{}{
+		"status": "SUCCESS",
+		"response": map[string]interface{}{
+			"avail":          yesNo(avail.available),
+			"type":           "registration",
+			"price":          price,
+			"firstYearPromo": "no",
+			"regularPrice":   price,
+			"premium":        yesNo(avail.premiumPrice != ""),
+			"additional": map[string]interface{}{
+				"renewal":  map[string]string{"type": "renewal", "price": prices.Renewal, "regularPrice": prices.Renewal},
+				"transfer": map[string]string{"type": "transfer", "price": prices.Transfer, "regularPrice": prices.Transfer},
+			},
+		},
+		"limits": map[string]interface{}{
+			"TTL":             strconv.Itoa(CheckDomainWindow),
+			"limit":           strconv.Itoa(CheckDomainLimit),
+			"used":            used,
+			"naturalLanguage": fmt.Sprintf("%d out of %d checks within %d seconds used.", used, CheckDomainLimit, CheckDomainWindow),
+		},
+	})
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, req Request) {
+	name := strings.ToLower(req.Domain)
+	prices, ok := s.pricing[name[strings.LastIndex(name, ".")+1:]]
+	if name == "" || !ok {
+		writeError(w, http.StatusBadRequest, "Invalid domain or unsupported TLD.")
+		return
+	}
+	if stringValue(req.Body["agreeToTerms"]) != "yes" {
+		writeError(w, http.StatusBadRequest, "You must agree to the terms of service.")
+		return
+	}
+
+	_, inAccount := s.domains[name]
+	avail := availability{available: !inAccount}
+	if override, ok := s.avail[name]; ok {
+		avail = override
+	}
+	if inAccount || !avail.available {
+		writeError(w, http.StatusBadRequest, "Domain is not available.")
+		return
+	}
+
+	years := 1
+	if v, ok := req.Body["years"]; ok {
+		n, err := strconv.Atoi(stringValue(v))
+		if err != nil || n < 1 || n > 10 {
+			writeError(w, http.StatusBadRequest, "Invalid number of years.")
+			return
+		}
+		years = n
+	}
+	// The first year costs the registration price, every further year the
+	// renewal price, as answered by domain/checkDomain.
+	price := prices.Registration
+	if avail.premiumPrice != "" {
+		price = avail.premiumPrice
+	}
+	expected := pennies(price) + pennies(prices.Renewal)*(years-1)
+	if stringValue(req.Body["cost"]) != strconv.Itoa(expected) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid cost. The cost of this domain is %d pennies.", expected))
+		return
+	}
+
+	now := time.Now().UTC()
+	s.domains[name] = &Domain{
+		Name:        name,
+		Status:      "ACTIVE",
+		CreateDate:  now.Format(porkbun.DateLayout),
+		ExpireDate:  now.AddDate(years, 0, 0).Format(porkbun.DateLayout),
+		AutoRenew:   true,
+		Nameservers: append([]string(nil), DefaultNameservers...),
+		Glue:        make(map[string][]string),
+	}
+	s.nextOrder++
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "domain": name, "cost": expected, "orderId": s.nextOrder, "balance": 0})
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, d *Domain, req Request) {
+	rec, errMsg := recordFromBody(d.Name, req.Body)
+	if errMsg != "" {
+		writeError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	for _, existing := range d.Records {
+		if existing.Name == rec.Name && existing.Type == rec.Type && existing.Content == rec.Content {
+			writeError(w, http.StatusBadRequest, "Create error: We were unable to create the DNS record.")
+			return
+		}
+	}
+
+	rec.ID = s.newID()
+	d.Records = append(d.Records, rec)
+	id, _ := strconv.Atoi(rec.ID)
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "id": id})
+}
+
+func (s *Server) editRecord(w http.ResponseWriter, d *Domain, req Request) {
+	i := d.recordIndex(req.Args)
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, "Edit error: Invalid record ID.")
+		return
+	}
+	rec, errMsg := recordFromBody(d.Name, req.Body)
+	if errMsg != "" {
+		writeError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	rec.ID = d.Records[i].ID
+	d.Records[i] = rec
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, d *Domain, req Request) {
+	i := d.recordIndex(req.Args)
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, "Delete error: Invalid record ID.")
+		return
+	}
+	d.Records = append(d.Records[:i], d.Records[i+1:]...)
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) retrieveRecords(w http.ResponseWriter, d *Domain, req Request) {
+	records := d.Records
+	if len(req.Args) > 0 {
+		records = nil
+		if i := d.recordIndex(req.Args); i >= 0 {
+			records = []Record{d.Records[i]}
+		}
+	}
+	if records == nil {
+		records = []Record{}
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "records": records})
+}
+
+func (s *Server) retrieveByNameType(w http.ResponseWriter, d *Domain, req Request) {
+	records := []Record{}
+	for _, i := range d.nameTypeIndexes(req.Args) {
+		records = append(records, d.Records[i])
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "records": records})
+}
+
+func (s *Server) editByNameType(w http.ResponseWriter, d *Domain, req Request) {
+	if len(req.Args) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid type.")
+		return
+	}
+	for _, i := range d.nameTypeIndexes(req.Args) {
+		body := map[string]interface{}{"name": d.Records[i].Name, "type": d.Records[i].Type, "notes": d.Records[i].Notes}
+		for _, field := range []string{"content", "ttl", "prio"} {
+			body[field] = req.Body[field]
+		}
+		// Unlike dns/edit, notes that are not sent are kept.
+		if notes, ok := req.Body["notes"]; ok {
+			body["notes"] = notes
+		}
+		rec, errMsg := recordFromBody(d.Name, body)
+		if errMsg != "" {
+			writeError(w, http.StatusBadRequest, errMsg)
+			return
+		}
+		rec.ID = d.Records[i].ID
+		d.Records[i] = rec
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) deleteByNameType(w http.ResponseWriter, d *Domain, req Request) {
+	if len(req.Args) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid type.")
+		return
+	}
+	var ids []string
+	for _, i := range d.nameTypeIndexes(req.Args) {
+		ids = append(ids, d.Records[i].ID)
+	}
+	d.Records = slices.DeleteFunc(d.Records, func(r Record) bool { return slices.Contains(ids, r.ID) })
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) getNameservers(w http.ResponseWriter, d *Domain, _ Request) {
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "ns": d.Nameservers})
+}
+
+func (s *Server) updateNameservers(w http.ResponseWriter, d *Domain, req Request) {
+	ns := stringSlice(req.Body["ns"])
+	if len(ns) == 0 {
+		writeError(w, http.StatusBadRequest, "At least one nameserver is required.")
+		return
+	}
+	d.Nameservers = ns
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) createGlue(w http.ResponseWriter, d *Domain, req Request) {
+	host, ok := singleArg(req.Args)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "A glue host is required.")
+		return
+	}
+	if _, exists := d.Glue[host]; exists {
+		writeError(w, http.StatusBadRequest, "Glue record already exists.")
+		return
+	}
+	s.setGlue(w, d, host, req.Body)
+}
+
+func (s *Server) updateGlue(w http.ResponseWriter, d *Domain, req Request) {
+	host, ok := singleArg(req.Args)
+	if _, exists := d.Glue[host]; !ok || !exists {
+		writeError(w, http.StatusBadRequest, "Could not find glue record.")
+		return
+	}
+	s.setGlue(w, d, host, req.Body)
+}
+
+func (s *Server) setGlue(w http.ResponseWriter, d *Domain, host string, body map[string]interface{}) {
+	ips := stringSlice(body["ips"])
+	if len(ips) == 0 {
+		writeError(w, http.StatusBadRequest, "At least one IP address is required.")
+		return
+	}
+	for _, ip := range ips {
+		if net.ParseIP(ip) == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid IP address: %s", ip))
+			return
+		}
+	}
+	d.Glue[host] = ips
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) deleteGlue(w http.ResponseWriter, d *Domain, req Request) {
+	host, _ := singleArg(req.Args)
+	if _, exists := d.Glue[host]; !exists {
+		writeError(w, http.StatusBadRequest, "Could not find glue record.")
+		return
+	}
+	delete(d.Glue, host)
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) getGlue(w http.ResponseWriter, d *Domain, _ Request) {
+	hostNames := make([]string, 0, len(d.Glue))
+	for host := range d.Glue {
+		hostNames = append(hostNames, host)
+	}
+	sort.Strings(hostNames)
+
+	hosts := make([][]interface{}, 0, len(hostNames))
+	for _, host := range hostNames {
+		ips := map[string][]string{"v4": {}, "v6": {}}
+		for _, ip := range d.Glue[host] {
+			if strings.Contains(ip, ":") {
+				ips["v6"] = append(ips["v6"], ip)
+			} else {
+				ips["v4"] = append(ips["v4"], ip)
+			}
+		}
+		hosts = append(hosts, []interface{}{host + "." + d.Name, ips})
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "hosts": hosts})
+}
+
+func (s *Server) addDnssec(w http.ResponseWriter, d *Domain, req Request) {
+	rec := dnssecFromBody(req.Body)
+	if rec.KeyTag == "" || rec.Algorithm == "" || rec.DigestType == "" || rec.Digest == "" {
+		writeError(w, http.StatusBadRequest, "keyTag, algorithm, digestType and digest are required.")
+		return
+	}
+	for _, existing := range d.Dnssec {
+		if existing == rec {
+			writeError(w, http.StatusBadRequest, "DS record already exists.")
+			return
+		}
+	}
+	d.Dnssec = append(d.Dnssec, rec)
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) getDnssec(w http.ResponseWriter, d *Domain, _ Request) {
+	records := d.Dnssec
+	if records == nil {
+		records = []DnssecRecord{}
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "dsRecords": records})
+}
+
+func (s *Server) deleteDnssec(w http.ResponseWriter, d *Domain, req Request) {
+	rec := dnssecFromBody(req.Body)
+	for i, existing := range d.Dnssec {
+		if existing == rec {
+			d.Dnssec = append(d.Dnssec[:i], d.Dnssec[i+1:]...)
+			writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, "DS record not found.")
+}
+
+func (s *Server) addUrlForward(w http.ResponseWriter, d *Domain, req Request) {
+	forward := UrlForward{
+		Subdomain:   strings.ToLower(stringValue(req.Body["subdomain"])),
+		Location:    stringValue(req.Body["location"]),
+		Type:        stringValue(req.Body["type"]),
+		IncludePath: stringValue(req.Body["includePath"]),
+		Wildcard:    stringValue(req.Body["wildcard"]),
+	}
+	if forward.Location == "" {
+		writeError(w, http.StatusBadRequest, "A location is required.")
+		return
+	}
+	if forward.Type != "temporary" && forward.Type != "permanent" {
+		writeError(w, http.StatusBadRequest, "Invalid forward type.")
+		return
+	}
+	for _, flag := range []string{forward.IncludePath, forward.Wildcard} {
+		if flag != "yes" && flag != "no" {
+			writeError(w, http.StatusBadRequest, "includePath and wildcard must be yes or no.")
+			return
+		}
+	}
+	for _, existing := range d.UrlForwards {
+		if existing.Subdomain == forward.Subdomain {
+			writeError(w, http.StatusBadRequest, "A URL forward already exists for this subdomain.")
+			return
+		}
+	}
+
+	forward.ID = s.newID()
+	d.UrlForwards = append(d.UrlForwards, forward)
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+}
+
+func (s *Server) getUrlForwarding(w http.ResponseWriter, d *Domain, _ Request) {
+	forwards := d.UrlForwards
+	if forwards == nil {
+		forwards = []UrlForward{}
+	}
+	writeJSON(w, map[string]interface{}{"status": "SUCCESS", "forwards": forwards})
+}
+
+func (s *Server) deleteUrlForward(w http.ResponseWriter, d *Domain, req Request) {
+	id, _ := singleArg(req.Args)
+	for i, forward := range d.UrlForwards {
+		if forward.ID == id {
+			d.UrlForwards = append(d.UrlForwards[:i], d.UrlForwards[i+1:]...)
+			writeJSON(w, map[string]interface{}{"status": "SUCCESS"})
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, "Could not find URL forward.")
+}
+
+// nameTypeIndexes returns the indexes of the records matching the
+// [type, subdomain] path arguments of the *ByNameType endpoints.
+func (d *Domain) nameTypeIndexes(args []string) []int {
+	if len(args) == 0 || len(args) > 2 {
+		return nil
+	}
+	recordType := strings.ToUpper(args[0])
+	name := d.Name
+	if len(args) == 2 {
+		name = fqdn(args[1], d.Name)
+	}
+
+	var matches []int
+	for i, rec := range d.Records {
+		if rec.Type == recordType && rec.Name == name {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+func (d *Domain) recordIndex(args []string) int {
+	id, ok := singleArg(args)
+	if !ok {
+		return -1
+	}
+	for i, rec := range d.Records {
+		if rec.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordFromBody validates a dns/create or dns/edit payload and returns the
+// record the way Porkbun would store it: with an upper-case type, a
+// fully-qualified name, expanded IPv6 addresses and without trailing dots on
+// hostnames.
+func recordFromBody(domain string, body map[string]interface{}) (Record, string) {
+	rec := Record{
+		Name:    fqdn(stringValue(body["name"]), domain),
+		Type:    strings.ToUpper(stringValue(body["type"])),
+		Content: stringValue(body["content"]),
+		TTL:     stringValue(body["ttl"]),
+		Prio:    stringValue(body["prio"]),
+		Notes:   stringValue(body["notes"]),
+	}
+
+	if rec.Type == "" {
+		return Record{}, "Invalid type."
+	}
+	if rec.Content == "" {
+		return Record{}, "Content is required."
+	}
+	switch rec.Type {
+	case "AAAA":
+		if addr, err := netip.ParseAddr(rec.Content); err == nil {
+			rec.Content = addr.StringExpanded()
+		}
+	case "CNAME", "ALIAS", "MX", "NS":
+		rec.Content = strings.TrimSuffix(rec.Content, ".")
+	}
+	if rec.TTL == "" {
+		rec.TTL = "600"
+	}
+	if _, err := strconv.Atoi(rec.TTL); err != nil {
+		return Record{}, "Invalid TTL."
+	}
+	if rec.Prio == "" {
+		rec.Prio = "0"
+	}
+	return rec, ""
+}
+
+func dnssecFromBody(body map[string]interface{}) DnssecRecord {
+	return DnssecRecord{
+		Algorithm:  stringValue(body["algorithm"]),
+		DigestType: stringValue(body["digestType"]),
+		KeyTag:     stringValue(body["keyTag"]),
+		Digest:     stringValue(body["digest"]),
+	}
+}
+
+func fqdn(name, domain string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	switch {
+	case name == "" || name == "@" || name == domain:
+		return domain
+	case strings.HasSuffix(name, "."+domain):
+		return name
+	default:
+		return name + "." + domain
+	}
+}
+
+func singleArg(args []string) (string, bool) {
+	if len(args) != 1 || args[0] == "" {
+		return "", false
+	}
+	return args[0], true
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func stringSlice(v interface{}) []string {
+	items, _ := v.([]interface{})
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, stringValue(item))
+	}
+	return out
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"status": "ERROR", "message": message})
+}
+
+func pennies(price string) int {
+	dollars, _ := strconv.ParseFloat(price, 64)
+	return int(math.Round(dollars * 100))
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
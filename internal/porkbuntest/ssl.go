@@ -0,0 +1,70 @@
+package porkbuntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// SslBundle is the certificate bundle returned by ssl/retrieve.
+type SslBundle struct {
+	CertificateChain string
+	PrivateKey       string
+	PublicKey        string
+}
+
+// NewSslBundle returns a self-signed certificate for the given DNS names that
+// expires at notAfter.
+func NewSslBundle(dnsNames []string, notAfter time.Time) (SslBundle, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return SslBundle{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notAfter.AddDate(0, -3, 0),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return SslBundle{}, err
+	}
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return SslBundle{}, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return SslBundle{}, err
+	}
+
+	return SslBundle{
+		CertificateChain: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})),
+		PrivateKey:       string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})),
+		PublicKey:        string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+	}, nil
+}
+
+func (s *Server) retrieveSsl(w http.ResponseWriter, d *Domain, _ Request) {
+	if d.Ssl == nil {
+		writeError(w, http.StatusBadRequest, "The SSL certificate is not ready for this domain.")
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"status":           "SUCCESS",
+		"certificatechain": d.Ssl.CertificateChain,
+		"privatekey":       d.Ssl.PrivateKey,
+		"publickey":        d.Ssl.PublicKey,
+	})
+}
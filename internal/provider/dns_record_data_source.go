@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &dnsRecordDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsRecordDataSource{}
+)
+
+func NewDnsRecordDataSource() datasource.DataSource {
+	return &dnsRecordDataSource{}
+}
+
+type dnsRecordDataSource struct {
+	client *porkbun.Client
+}
+
+type dnsRecordDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Domain  types.String `tfsdk:"domain"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Content types.String `tfsdk:"content"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	Prio    types.Int64  `tfsdk:"prio"`
+	Notes   types.String `tfsdk:"notes"`
+}
+
+func (d *dnsRecordDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (d *dnsRecordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ruft einen einzelnen DNS-Eintrag anhand von Name und Typ ab, ohne die ganze Zone herunterzuladen.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Die ID des DNS-Eintrags.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der Domainname, in dem gesucht wird.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Die Subdomain des Eintrags. Leer lassen für die Domain selbst.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Der Typ des Eintrags (z. B. A, MX, TXT).",
+				Required:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Der Inhalt des Eintrags.",
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Die TTL des Eintrags in Sekunden.",
+				Computed:    true,
+			},
+			"prio": schema.Int64Attribute{
+				Description: "Die Priorität des Eintrags (bei MX- und SRV-Einträgen).",
+				Computed:    true,
+			},
+			"notes": schema.StringAttribute{
+				Description: "Die Notizen zum Eintrag aus der Porkbun-Weboberfläche.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *dnsRecordDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Data Source", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *dnsRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dnsRecordDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	recordType := strings.ToUpper(config.Type.ValueString())
+	name := config.Name.ValueString()
+
+	records, err := d.client.RetrieveRecordsByNameType(ctx, domain, recordType, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen des DNS-Eintrags", fmt.Sprintf("Konnte %s-Einträge für %q in %s nicht abrufen: %s", recordType, name, domain, err.Error()))
+		return
+	}
+	switch len(records) {
+	case 1:
+	case 0:
+		resp.Diagnostics.AddError("DNS-Eintrag nicht gefunden", fmt.Sprintf("In %s gibt es keinen %s-Eintrag mit dem Namen %q.", domain, recordType, name))
+		return
+	default:
+		resp.Diagnostics.AddError(
+			"Mehrdeutiger DNS-Eintrag",
+			fmt.Sprintf("In %s gibt es %d %s-Einträge mit dem Namen %q. Verwende porkbun_dns_records, um alle abzurufen.", domain, len(records), recordType, name),
+		)
+		return
+	}
+	record := records[0]
+
+	state := config
+	state.ID = types.StringValue(record.ID)
+	state.Content = types.StringValue(record.Content)
+	state.TTL = types.Int64Value(int64(record.TTL))
+	state.Prio = types.Int64Value(int64(record.Prio))
+	state.Notes = types.StringValue(record.Notes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
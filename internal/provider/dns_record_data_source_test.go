@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDnsRecordDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Records = []porkbuntest.Record{
+			{ID: "1", Name: testAccDomain, Type: "MX", Content: "mail.example.net", TTL: "3600", Prio: "10"},
+			{ID: "2", Name: "www." + testAccDomain, Type: "A", Content: "192.0.2.1", TTL: "600", Prio: "0"},
+			{ID: "3", Name: "txt." + testAccDomain, Type: "TXT", Content: "one", TTL: "600", Prio: "0"},
+			{ID: "4", Name: "txt." + testAccDomain, Type: "TXT", Content: "two", TTL: "600", Prio: "0"},
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_dns_record" "www" {
+  domain = %[1]q
+  name   = "www"
+  type   = "a"
+}
+
+data "porkbun_dns_record" "mx" {
+  domain = %[1]q
+  type   = "MX"
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_dns_record.www", "id", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_record.www", "content", "192.0.2.1"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_record.mx", "id", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_record.mx", "prio", "10"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_record.mx", "ttl", "3600"),
+					func(*terraform.State) error {
+						if n := server.RequestCount("dns/retrieve"); n != 0 {
+							return fmt.Errorf("expected no zone download, got %d dns/retrieve requests", n)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_dns_record" "txt" {
+  domain = %q
+  name   = "txt"
+  type   = "TXT"
+}
+`, testAccDomain),
+				ExpectError: regexp.MustCompile(`Mehrdeutiger DNS-Eintrag`),
+			},
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_dns_record" "none" {
+  domain = %q
+  name   = "nope"
+  type   = "A"
+}
+`, testAccDomain),
+				ExpectError: regexp.MustCompile(`DNS-Eintrag nicht gefunden`),
+			},
+		},
+	})
+}
@@ -2,23 +2,30 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &dnsRecordResource{}
-	_ resource.ResourceWithConfigure   = &dnsRecordResource{}
-	_ resource.ResourceWithImportState = &dnsRecordResource{}
+	_ resource.Resource                     = &dnsRecordResource{}
+	_ resource.ResourceWithConfigure        = &dnsRecordResource{}
+	_ resource.ResourceWithImportState      = &dnsRecordResource{}
+	_ resource.ResourceWithConfigValidators = &dnsRecordResource{}
+	_ resource.ResourceWithUpgradeState     = &dnsRecordResource{}
 )
 
 func NewDnsRecordResource() resource.Resource {
@@ -26,17 +33,21 @@ func NewDnsRecordResource() resource.Resource {
 }
 
 type dnsRecordResource struct {
-	client *porkbun.Client
+	client               *porkbun.Client
+	adoptExistingRecords bool
+	ownershipMarker      string
 }
 
 type dnsRecordResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Domain  types.String `tfsdk:"domain"`
-	Name    types.String `tfsdk:"name"`
-	Type    types.String `tfsdk:"type"`
-	Content types.String `tfsdk:"content"`
-	TTL     types.String `tfsdk:"ttl"`
-	Prio    types.String `tfsdk:"prio"`
+	ID      types.String       `tfsdk:"id"`
+	Domain  types.String       `tfsdk:"domain"`
+	Name    recordNameValue    `tfsdk:"name"`
+	Type    recordTypeValue    `tfsdk:"type"`
+	Content recordContentValue `tfsdk:"content"`
+	TTL     types.Int64        `tfsdk:"ttl"`
+	Prio    types.Int64        `tfsdk:"prio"`
+	Notes   types.String       `tfsdk:"notes"`
+	Adopt   types.Bool         `tfsdk:"adopt_existing"`
 }
 
 func (r *dnsRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,6 +56,8 @@ func (r *dnsRecordResource) Metadata(_ context.Context, req resource.MetadataReq
 
 func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed ttl and prio from strings to numbers.
+		Version:     1,
 		Description: "Manages a DNS record on Porkbun.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -62,48 +75,71 @@ func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "The subdomain for the record, if any. Use an empty string for the root domain.",
+				Description: "The subdomain for the record, if any. Use an empty string or \"@\" for the root domain. Fully-qualified names are accepted as well.",
+				CustomType:  recordNameType{},
 				Optional:    true,
 				Computed:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "The type of the DNS record (e.g., A, CNAME, TXT).",
+				Description: "The type of the DNS record: A, AAAA, CNAME, ALIAS, MX, TXT, NS, SRV, TLSA, CAA, HTTPS, SVCB or SSHFP. Case-insensitive.",
+				CustomType:  recordTypeType{},
 				Required:    true,
 			},
 			"content": schema.StringAttribute{
 				Description: "The content/value of the DNS record.",
+				CustomType:  recordContentType{},
 				Required:    true,
 			},
-			"ttl": schema.StringAttribute{
-				Description: "The Time To Live (TTL) of the record in seconds.",
+			"ttl": schema.Int64Attribute{
+				Description: "The Time To Live (TTL) of the record in seconds. Must be at least 600.",
 				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"prio": schema.StringAttribute{
-				Description: "The priority of the record (for MX and SRV records only).",
+			"prio": schema.Int64Attribute{
+				Description: "The priority of the record, between 0 and 65535. Required for MX and SRV records and not allowed for other types.",
 				Optional:    true,
 				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.Int64{
+					prioPlanModifier{},
+					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"notes": schema.StringAttribute{
+				Description: "Notes on the record, shown in the Porkbun web interface. Defaults to no notes.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Take over an identical existing record (same name, type and content) instead of failing to create it. Records carrying the provider's record_ownership_marker are taken over unless this is set to false, others only if it is true. Defaults to the provider's adopt_existing_records.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
+func (r *dnsRecordResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return dnsRecordConfigValidators()
+}
+
 func (r *dnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*porkbun.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *porkbun.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.adoptExistingRecords = data.adoptExistingRecords
+	r.ownershipMarker = data.ownershipMarker
 }
 
 func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -114,28 +150,18 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	record := porkbun.DnsRecord{
-		Name:    plan.Name.ValueString(),
-		Type:    plan.Type.ValueString(),
-		Content: plan.Content.ValueString(),
-		TTL:     plan.TTL.ValueString(),
-		Prio:    plan.Prio.ValueString(),
+	record := r.recordFromPlan(plan)
+	recordID, err := r.client.CreateRecord(ctx, plan.Domain.ValueString(), record)
+	if errors.Is(err, porkbun.ErrDuplicateRecord) && r.mayAdopt(plan) {
+		recordID, err = r.adoptRecord(ctx, plan.Domain.ValueString(), record, err, r.adoptExisting(plan))
 	}
-
-	recordID, err := r.client.CreateRecord(plan.Domain.ValueString(), record)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating DNS record", "Could not create record, unexpected error: "+err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(recordID)
-
-	if plan.TTL.IsUnknown() || plan.TTL.IsNull() {
-		plan.TTL = types.StringValue("300")
-	}
-	if plan.Name.IsUnknown() || plan.Name.IsNull() {
-		plan.Name = types.StringValue("")
-	}
+	setRecordDefaults(&plan)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -152,20 +178,17 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	tflog.Info(ctx, "Reading all records for domain", map[string]interface{}{"domain": state.Domain.ValueString()})
-	records, err := r.client.RetrieveRecords(state.Domain.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading Porkbun records", "Could not retrieve records for domain "+state.Domain.ValueString()+": "+err.Error())
-		return
-	}
-
-	var foundRecord *porkbun.DnsRecord
-	for _, record := range records {
-		if record.ID == state.ID.ValueString() {
-			rec := record
-			foundRecord = &rec
-			break
+	tflog.Info(ctx, "Reading DNS record", map[string]interface{}{"domain": state.Domain.ValueString(), "id": state.ID.ValueString()})
+	foundRecord, err := r.client.RetrieveRecord(ctx, state.Domain.ValueString(), state.ID.ValueString())
+	if errors.Is(err, porkbun.ErrNotFound) {
+		foundRecord, err = r.refindRecord(ctx, state)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading Porkbun records", "Could not look up record by name and type: "+err.Error())
+			return
 		}
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error reading Porkbun records", "Could not retrieve record "+state.ID.ValueString()+" for domain "+state.Domain.ValueString()+": "+err.Error())
+		return
 	}
 
 	if foundRecord == nil {
@@ -173,19 +196,38 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if foundRecord.ID != state.ID.ValueString() {
+		tflog.Warn(ctx, "DNS record ID changed, tracking the record under its new ID", map[string]interface{}{
+			"old_id": state.ID.ValueString(),
+			"new_id": foundRecord.ID,
+		})
+		state.ID = types.StringValue(foundRecord.ID)
+	}
 
-	recordName := foundRecord.Name
+	// Keep name and content as written in the configuration as long as they
+	// still mean the same, e.g. "@", a fully-qualified name or a hostname with
+	// a trailing dot.
 	domainName := state.Domain.ValueString()
-	normalizedName := strings.TrimSuffix(recordName, "."+domainName)
-	if normalizedName == domainName {
-		normalizedName = ""
+	if state.Name.IsNull() || !recordNameMatches(state.Name.ValueString(), foundRecord.Name, domainName) {
+		state.Name = newRecordNameValue(relativeRecordName(foundRecord.Name, domainName))
+	}
+	state.Type = newRecordTypeValue(foundRecord.Type)
+	if state.Content.IsNull() || !recordContentEqual(foundRecord.Type, state.Content.ValueString(), foundRecord.Content) {
+		state.Content = newRecordContentValue(foundRecord.Content)
+	}
+	state.TTL = types.Int64Value(int64(foundRecord.TTL))
+	state.Prio = types.Int64Null()
+	if recordTypeUsesPrio(foundRecord.Type) {
+		state.Prio = types.Int64Value(int64(foundRecord.Prio))
+	}
+	if notes, ok := stripOwnershipMarker(foundRecord.Notes, r.ownershipMarker); ok {
+		state.Notes = types.StringValue(notes)
+	} else {
+		// Not marked as managed by Terraform yet. Dropping the notes from
+		// state makes the next plan write them again, with the marker.
+		tflog.Info(ctx, "DNS record lacks the ownership marker, it is added on the next apply", map[string]interface{}{"id": state.ID.ValueString()})
+		state.Notes = types.StringNull()
 	}
-
-	state.Name = types.StringValue(normalizedName)
-	state.Type = types.StringValue(foundRecord.Type)
-	state.Content = types.StringValue(foundRecord.Content)
-	state.TTL = types.StringValue(foundRecord.TTL)
-	state.Prio = types.StringValue(foundRecord.Prio)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -202,19 +244,13 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	record := porkbun.DnsRecord{
-		Name:    plan.Name.ValueString(),
-		Type:    plan.Type.ValueString(),
-		Content: plan.Content.ValueString(),
-		TTL:     plan.TTL.ValueString(),
-		Prio:    plan.Prio.ValueString(),
-	}
-
-	err := r.client.EditRecord(plan.Domain.ValueString(), plan.ID.ValueString(), record)
+	record := r.recordFromPlan(plan)
+	err := r.client.EditRecord(ctx, plan.Domain.ValueString(), plan.ID.ValueString(), record)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating DNS record", "Could not update record, unexpected error: "+err.Error())
 		return
 	}
+	setRecordDefaults(&plan)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -231,9 +267,9 @@ func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.DeleteRecord(state.Domain.ValueString(), state.ID.ValueString())
+	err := r.client.DeleteRecord(ctx, state.Domain.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "record not found") {
+		if errors.Is(err, porkbun.ErrNotFound) {
 			tflog.Warn(ctx, "Record to be deleted was not found on remote. Ignoring.")
 			return
 		}
@@ -244,14 +280,265 @@ func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 
 func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "":
+		domain, recordType, name := parts[0], parts[1], relativeRecordName(parts[2], parts[0])
+		records, err := r.client.RetrieveRecordsByNameType(ctx, domain, recordType, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing DNS record", fmt.Sprintf("Could not look up %s records named %q: %s", recordType, name, err))
+			return
+		}
+		if len(records) != 1 {
+			resp.Diagnostics.AddError(
+				"Error importing DNS record",
+				fmt.Sprintf("Expected exactly one %s record named %q in %s, found %d. Import by domain/record_id instead.", recordType, name, domain, len(records)),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), records[0].ID)...)
+
+	default:
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: domain/record_id. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: domain/record_id or domain/type/name. Got: %q", req.ID),
+		)
+	}
+}
+
+// dnsRecordResourceModelV0 is the state of schema version 0, which stored ttl
+// and prio as strings.
+type dnsRecordResourceModelV0 struct {
+	ID      types.String `tfsdk:"id"`
+	Domain  types.String `tfsdk:"domain"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Content types.String `tfsdk:"content"`
+	TTL     types.String `tfsdk:"ttl"`
+	Prio    types.String `tfsdk:"prio"`
+}
+
+func (r *dnsRecordResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":      schema.StringAttribute{Computed: true},
+					"domain":  schema.StringAttribute{Required: true},
+					"name":    schema.StringAttribute{Optional: true, Computed: true},
+					"type":    schema.StringAttribute{Required: true},
+					"content": schema.StringAttribute{Required: true},
+					"ttl":     schema.StringAttribute{Optional: true, Computed: true},
+					"prio":    schema.StringAttribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: upgradeDnsRecordStateV0,
+		},
+	}
+}
+
+func upgradeDnsRecordStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior dnsRecordResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := dnsRecordResourceModel{
+		ID:      prior.ID,
+		Domain:  prior.Domain,
+		Name:    recordNameValue{StringValue: prior.Name},
+		Type:    recordTypeValue{StringValue: prior.Type},
+		Content: recordContentValue{StringValue: prior.Content},
+		TTL:     upgradeRecordInt(prior.TTL, "ttl", &resp.Diagnostics),
+		Prio:    upgradeRecordInt(prior.Prio, "prio", &resp.Diagnostics),
+		// Version 0 had no notes, the next refresh reads them.
+		Notes: types.StringNull(),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// upgradeRecordInt converts a version 0 ttl or prio. Unset values stay null.
+func upgradeRecordInt(value types.String, attribute string, diags *diag.Diagnostics) types.Int64 {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return types.Int64Null()
+	}
+	n, err := strconv.ParseInt(value.ValueString(), 10, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Unable to Upgrade DNS Record State",
+			fmt.Sprintf("%s must be a whole number, got: %q. Remove the record from state and import it again.", attribute, value.ValueString()),
 		)
+		return types.Int64Null()
+	}
+	return types.Int64Value(n)
+}
+
+// refindRecord looks for a record with the name, type and content from state.
+// Porkbun hands out new IDs in some cases, e.g. when a record is edited in the
+// web interface, so a missing ID does not necessarily mean the record is gone.
+func (r *dnsRecordResource) refindRecord(ctx context.Context, state dnsRecordResourceModel) (*porkbun.DnsRecord, error) {
+	if state.Type.ValueString() == "" || state.Content.ValueString() == "" {
+		return nil, nil
+	}
+
+	domain := state.Domain.ValueString()
+	records, err := r.client.RetrieveRecordsByNameType(ctx, domain, state.Type.ValueString(), relativeRecordName(state.Name.ValueString(), domain))
+	if err != nil {
+		return nil, err
+	}
+
+	var match *porkbun.DnsRecord
+	for i := range records {
+		if !recordContentEqual(state.Type.ValueString(), records[i].Content, state.Content.ValueString()) {
+			continue
+		}
+		if match != nil {
+			// Ambiguous, better to recreate than to adopt the wrong record.
+			return nil, nil
+		}
+		match = &records[i]
+	}
+	return match, nil
+}
+
+// setRecordDefaults fills in the values Porkbun uses for attributes that are
+// not configured.
+func setRecordDefaults(plan *dnsRecordResourceModel) {
+	if plan.TTL.IsUnknown() || plan.TTL.IsNull() {
+		plan.TTL = types.Int64Value(minRecordTTL)
+	}
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		plan.Name = newRecordNameValue("")
+	}
+	if plan.Prio.IsUnknown() {
+		plan.Prio = types.Int64Null()
+	}
+}
+
+// prioPlanModifier plans prio as null for record types without a priority, so
+// that changing e.g. an MX record into an A record drops the old priority.
+type prioPlanModifier struct{}
+
+func (m prioPlanModifier) Description(_ context.Context) string {
+	return "prio is null for record types other than MX and SRV"
+}
+
+func (m prioPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m prioPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if !req.ConfigValue.IsNull() {
 		return
 	}
+	var recordType recordTypeValue
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if recordType.IsNull() || recordType.IsUnknown() || recordTypeUsesPrio(recordType.ValueString()) {
+		return
+	}
+	resp.PlanValue = types.Int64Null()
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+// adoptExisting reports whether a conflicting record without the ownership
+// marker is adopted for plan.
+func (r *dnsRecordResource) adoptExisting(plan dnsRecordResourceModel) bool {
+	if plan.Adopt.IsNull() || plan.Adopt.IsUnknown() {
+		return r.adoptExistingRecords
+	}
+	return plan.Adopt.ValueBool()
+}
+
+// mayAdopt reports whether Create looks for a conflicting record to adopt at
+// all. Records carrying the ownership marker were written by this provider,
+// e.g. by a create that went through but failed in the provider, and are
+// adopted unless adopt_existing is explicitly false.
+func (r *dnsRecordResource) mayAdopt(plan dnsRecordResourceModel) bool {
+	if !plan.Adopt.IsNull() && !plan.Adopt.IsUnknown() {
+		return plan.Adopt.ValueBool()
+	}
+	return r.ownershipMarker != "" || r.adoptExistingRecords
+}
+
+// adoptRecord looks for a record identical to record after creating it was
+// refused as a duplicate with createErr. It is updated to match the rest of the
+// plan, and its ID is returned. createErr is returned if there is none, or if
+// the record lacks the ownership marker and adoptUnmarked is false.
+func (r *dnsRecordResource) adoptRecord(ctx context.Context, domain string, record porkbun.DnsRecord, createErr error, adoptUnmarked bool) (string, error) {
+	records, err := r.client.RetrieveRecords(ctx, domain)
+	if err != nil {
+		return "", fmt.Errorf("%w; looking for an existing record to adopt failed: %s", createErr, err)
+	}
+
+	for _, existing := range records {
+		if !strings.EqualFold(existing.Type, record.Type) ||
+			relativeRecordName(existing.Name, domain) != record.Name ||
+			!recordContentEqual(record.Type, existing.Content, record.Content) {
+			continue
+		}
+
+		_, marked := stripOwnershipMarker(existing.Notes, r.ownershipMarker)
+		if (r.ownershipMarker == "" || !marked) && !adoptUnmarked {
+			tflog.Warn(ctx, "Not adopting existing DNS record without the ownership marker, set adopt_existing to take it over", map[string]interface{}{"domain": domain, "id": existing.ID})
+			return "", createErr
+		}
+
+		tflog.Info(ctx, "Adopting existing DNS record", map[string]interface{}{"domain": domain, "id": existing.ID})
+		// ttl, prio and notes of the existing record may differ.
+		if err := r.client.EditRecord(ctx, domain, existing.ID, record); err != nil {
+			return "", fmt.Errorf("could not update adopted record %s: %w", existing.ID, err)
+		}
+		return existing.ID, nil
+	}
+	return "", createErr
+}
+
+// recordFromPlan builds the API representation of plan. The name is sent
+// relative to the domain, ttl and prio are left out when they are not set.
+// The ownership marker, if any, is added to the notes.
+func (r *dnsRecordResource) recordFromPlan(plan dnsRecordResourceModel) porkbun.DnsRecord {
+	return porkbun.DnsRecord{
+		Name:    relativeRecordName(plan.Name.ValueString(), plan.Domain.ValueString()),
+		Type:    plan.Type.ValueString(),
+		Content: plan.Content.ValueString(),
+		TTL:     porkbun.FlexInt(plan.TTL.ValueInt64()),
+		Prio:    porkbun.FlexInt(plan.Prio.ValueInt64()),
+		Notes:   addOwnershipMarker(plan.Notes.ValueString(), r.ownershipMarker),
+	}
+}
+
+// addOwnershipMarker appends marker to notes, separated by a space.
+func addOwnershipMarker(notes, marker string) string {
+	switch {
+	case marker == "":
+		return notes
+	case notes == "":
+		return marker
+	default:
+		return notes + " " + marker
+	}
+}
+
+// stripOwnershipMarker removes marker added by addOwnershipMarker from notes.
+// It reports false if a marker is set but notes do not carry it.
+func stripOwnershipMarker(notes, marker string) (string, bool) {
+	switch {
+	case marker == "":
+		return notes, true
+	case notes == marker:
+		return "", true
+	case strings.HasSuffix(notes, " "+marker):
+		return strings.TrimSuffix(notes, " "+marker), true
+	default:
+		return notes, false
+	}
 }
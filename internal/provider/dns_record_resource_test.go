@@ -0,0 +1,728 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDnsRecordResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnsRecordConfig("www", "A", "192.0.2.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("porkbun_dns_record.test", "id"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "domain", testAccDomain),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "name", "www"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "type", "A"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "content", "192.0.2.1"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "ttl", "600"),
+					testAccCheckRecordContent(server, "www."+testAccDomain, "192.0.2.1"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				PreConfig:    server.ResetRequests,
+				RefreshState: true,
+				Check: func(*terraform.State) error {
+					if n := server.RequestCount("dns/retrieve"); n != 1 {
+						return fmt.Errorf("expected refresh to list the zone once, got %d dns/retrieve requests", n)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:      "porkbun_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportID("porkbun_dns_record.test", "domain", "id"),
+			},
+			{
+				ResourceName:      "porkbun_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccDomain + "/A/www",
+			},
+			{
+				ResourceName:  "porkbun_dns_record.test",
+				ImportState:   true,
+				ImportStateId: testAccDomain + "/AAAA/www",
+				ExpectError:   regexp.MustCompile(`found 0`),
+			},
+			{
+				Config: testAccDnsRecordConfig("www", "A", "192.0.2.2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("porkbun_dns_record.test", tfjsonpath.New("ttl"), knownvalue.Int64Exact(600)),
+						plancheck.ExpectKnownValue("porkbun_dns_record.test", tfjsonpath.New("prio"), knownvalue.Null()),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: testAccCheckRecordContent(server, "www."+testAccDomain, "192.0.2.2"),
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_rootMX(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "test" {
+  domain  = %q
+  name    = ""
+  type    = "MX"
+  content = "mail.example.net"
+  ttl     = 3600
+  prio    = 10
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "name", ""),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "prio", "10"),
+					testAccCheckRecordContent(server, testAccDomain, "mail.example.net"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				// An A record has no priority, the one of the MX record is dropped.
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "test" {
+  domain  = %q
+  name    = ""
+  type    = "A"
+  content = "192.0.2.1"
+  ttl     = 3600
+}
+`, testAccDomain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("porkbun_dns_record.test", tfjsonpath.New("prio"), knownvalue.Null()),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("porkbun_dns_record.test", "prio"),
+					testAccCheckRecordContent(server, testAccDomain, "192.0.2.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_drift(t *testing.T) {
+	server, factories := testAccSetup(t)
+	config := testAccDnsRecordConfig("drift", "TXT", "managed-by-terraform")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// Changed outside of Terraform: the content is reverted in place.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].Content = "changed-by-hand"
+					})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckRecordContent(server, "drift."+testAccDomain, "managed-by-terraform"),
+			},
+			{
+				// TXT values are case-sensitive, a changed case is drift too.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].Content = "Managed-By-Terraform"
+					})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckRecordContent(server, "drift."+testAccDomain, "managed-by-terraform"),
+			},
+			{
+				// Re-created with a new ID outside of Terraform: the record is
+				// found again by name, type and content and nothing changes.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].ID = "999"
+					})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.TestCheckResourceAttr("porkbun_dns_record.test", "id", "999"),
+			},
+			{
+				// Deleted outside of Terraform: the record is created again.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records = nil
+					})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckRecordContent(server, "drift."+testAccDomain, "managed-by-terraform"),
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_normalization(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "mx" {
+  domain  = %[1]q
+  name    = "@"
+  type    = "mx"
+  content = "Mail.Example.net."
+  prio    = 10
+}
+
+resource "porkbun_dns_record" "aaaa" {
+  domain  = %[1]q
+  name    = "V6.%[1]s."
+  type    = "aaaa"
+  content = "2001:DB8::1"
+}
+
+data "porkbun_dns_records" "all" {
+  domain     = %[1]q
+  depends_on = [porkbun_dns_record.mx, porkbun_dns_record.aaaa]
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dns_record.mx", "name", "@"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.mx", "type", "mx"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.aaaa", "name", "V6."+testAccDomain+"."),
+					resource.TestCheckResourceAttr("porkbun_dns_record.aaaa", "content", "2001:DB8::1"),
+					testAccCheckRecordContent(server, "v6."+testAccDomain, "2001:0db8:0000:0000:0000:0000:0000:0001"),
+					testAccCheckRecordContent(server, testAccDomain, "Mail.Example.net"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.porkbun_dns_records.all", "records.*", map[string]string{
+						"name":    "v6",
+						"type":    "AAAA",
+						"content": "2001:0db8:0000:0000:0000:0000:0000:0001",
+					}),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_notes(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	config := func(notes string) string {
+		return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "test" {
+  domain  = %[1]q
+  name    = "notes"
+  type    = "TXT"
+  content = "hello"
+  %[2]s
+}
+
+data "porkbun_dns_records" "all" {
+  domain     = %[1]q
+  depends_on = [porkbun_dns_record.test]
+}
+`, testAccDomain, notes)
+	}
+	checkNotes := func(want string) resource.TestCheckFunc {
+		return resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr("porkbun_dns_record.test", "notes", want),
+			resource.TestCheckResourceAttr("data.porkbun_dns_records.all", "records.0.notes", want),
+			func(*terraform.State) error {
+				d, _ := server.Domain(testAccDomain)
+				if len(d.Records) != 1 || d.Records[0].Notes != want {
+					return fmt.Errorf("expected notes %q in fake API, got %+v", want, d.Records)
+				}
+				return nil
+			},
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`notes = "OPS-1"`),
+				Check:  checkNotes("OPS-1"),
+			},
+			{
+				Config: config(`notes = "OPS-2, owned by ops"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkNotes("OPS-2, owned by ops"),
+			},
+			{
+				// Notes added by hand are drift and get removed.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].Notes = "added by hand"
+					})
+				},
+				Config: config(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkNotes(""),
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_adoptExisting(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Records = []porkbuntest.Record{
+			{ID: "42", Name: "left." + testAccDomain, Type: "A", Content: "192.0.2.1", TTL: "3600", Prio: "0", Notes: "left behind"},
+		}
+	})
+
+	config := func(providerSettings, adopt string) string {
+		return fmt.Sprintf(`
+provider "porkbun" {
+  api_key        = %q
+  secret_api_key = %q
+  max_retry_wait = "10ms"
+  %s
+}
+
+resource "porkbun_dns_record" "test" {
+  domain  = %q
+  name    = "left"
+  type    = "A"
+  content = "192.0.2.1"
+  notes   = "OPS-1"
+  %s
+}
+`, porkbuntest.DefaultAPIKey, porkbuntest.DefaultSecretKey, providerSettings, testAccDomain, adopt)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("", ""),
+				ExpectError: regexp.MustCompile(`Error creating DNS record`),
+			},
+			{
+				// Opting out per resource wins over the provider default.
+				Config:      config("adopt_existing_records = true", "adopt_existing = false"),
+				ExpectError: regexp.MustCompile(`Error creating DNS record`),
+			},
+			{
+				Config: config("adopt_existing_records = true", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "id", "42"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "ttl", "600"),
+					func(*terraform.State) error {
+						d, _ := server.Domain(testAccDomain)
+						if len(d.Records) != 1 || d.Records[0].TTL != "600" || d.Records[0].Notes != "OPS-1" {
+							return fmt.Errorf("expected the adopted record to be updated, got %+v", d.Records)
+						}
+						return nil
+					},
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_adoptExistingOtherError(t *testing.T) {
+	server, factories := testAccSetup(t)
+	t.Setenv("PORKBUN_ADOPT_EXISTING_RECORDS", "true")
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Records = []porkbuntest.Record{
+			{ID: "42", Name: "left." + testAccDomain, Type: "A", Content: "192.0.2.1", TTL: "3600", Prio: "0"},
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				// Only a create refused as a duplicate points at a conflicting
+				// record.
+				PreConfig: func() {
+					server.ResetRequests()
+					server.InjectFault(porkbuntest.ErrorFault("dns/create", 1, "Invalid API key. (002)"))
+				},
+				Config:      testAccDnsRecordConfig("left", "A", "192.0.2.1"),
+				ExpectError: regexp.MustCompile(`Invalid API key`),
+			},
+			{
+				// A validation error is returned as is, without looking for a
+				// record to adopt.
+				PreConfig: func() {
+					server.InjectFault(porkbuntest.ErrorFault("dns/create", 1, "Invalid TTL."))
+				},
+				Config:      testAccDnsRecordConfig("left", "A", "192.0.2.1"),
+				ExpectError: regexp.MustCompile(`Invalid TTL`),
+			},
+		},
+	})
+	testAccCheckNotAdopted(t, server, "3600")
+	for _, r := range server.Requests() {
+		if r.Endpoint == "dns/retrieve" && len(r.Args) == 0 {
+			t.Error("expected no zone download while looking for a record to adopt")
+			break
+		}
+	}
+}
+
+func TestAccDnsRecordResource_adoptMarked(t *testing.T) {
+	server, factories := testAccSetup(t)
+	t.Setenv("PORKBUN_RECORD_OWNERSHIP_MARKER", "managed-by-terraform")
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Records = []porkbuntest.Record{
+			{ID: "42", Name: "left." + testAccDomain, Type: "A", Content: "192.0.2.1", TTL: "3600", Prio: "0", Notes: "OPS-1"},
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				// Without the marker the record may have been created by hand,
+				// taking it over needs adopt_existing.
+				PreConfig:   server.ResetRequests,
+				Config:      testAccDnsRecordConfig("left", "A", "192.0.2.1"),
+				ExpectError: regexp.MustCompile(`Error creating DNS record`),
+			},
+			{
+				// Written by the provider, e.g. by a create whose response got
+				// lost, so it is taken over.
+				PreConfig: func() {
+					if n := server.RequestCount("dns/edit"); n != 0 {
+						t.Errorf("expected the unmarked record to be left alone, got %d dns/edit requests", n)
+					}
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].Notes = "OPS-1 managed-by-terraform"
+					})
+				},
+				Config: testAccDnsRecordConfig("left", "A", "192.0.2.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "id", "42"),
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "notes", ""),
+					func(*terraform.State) error {
+						d, _ := server.Domain(testAccDomain)
+						if len(d.Records) != 1 || d.Records[0].TTL != "600" || d.Records[0].Notes != "managed-by-terraform" {
+							return fmt.Errorf("expected the adopted record to be updated, got %+v", d.Records)
+						}
+						return nil
+					},
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_ownershipMarker(t *testing.T) {
+	server, factories := testAccSetup(t)
+	t.Setenv("PORKBUN_RECORD_OWNERSHIP_MARKER", "managed-by-terraform")
+
+	checkFakeNotes := func(want string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			d, _ := server.Domain(testAccDomain)
+			if len(d.Records) != 1 || d.Records[0].Notes != want {
+				return fmt.Errorf("expected notes %q in fake API, got %+v", want, d.Records)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNoRecords(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnsRecordConfig("owned", "TXT", "hello"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dns_record.test", "notes", ""),
+					checkFakeNotes("managed-by-terraform"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				// The marker was removed by hand, the next apply restores it.
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Records[0].Notes = ""
+					})
+				},
+				Config: testAccDnsRecordConfig("owned", "TXT", "hello"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: checkFakeNotes("managed-by-terraform"),
+			},
+		},
+	})
+}
+
+func TestAccDnsRecordResource_validation(t *testing.T) {
+	_, factories := testAccSetup(t)
+
+	config := func(recordType, content, extra string) string {
+		return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "test" {
+  domain  = %q
+  name    = "www"
+  type    = %q
+  content = %q
+  %s
+}
+`, testAccDomain, recordType, content, extra)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("SPF", "v=spf1 -all", ""),
+				ExpectError: regexp.MustCompile(`Unsupported DNS Record Type`),
+			},
+			{
+				Config:      config("A", "192.0.2.300", ""),
+				ExpectError: regexp.MustCompile(`(?s)Invalid DNS Record Content.*must be an IPv4`),
+			},
+			{
+				Config:      config("AAAA", "192.0.2.1", ""),
+				ExpectError: regexp.MustCompile(`(?s)Invalid DNS Record Content.*must be an IPv6`),
+			},
+			{
+				Config:      config("SRV", "5060 sip.example.com", `prio = 10`),
+				ExpectError: regexp.MustCompile(`Invalid DNS Record Content`),
+			},
+			{
+				Config:      config("CAA", "0 issue", ""),
+				ExpectError: regexp.MustCompile(`Invalid DNS Record Content`),
+			},
+			{
+				Config:      config("MX", "mail.example.net", ""),
+				ExpectError: regexp.MustCompile(`Missing DNS Record Priority`),
+			},
+			{
+				Config:      config("MX", "mail.example.net", `prio = 70000`),
+				ExpectError: regexp.MustCompile(`Invalid DNS Record Priority`),
+			},
+			{
+				Config:      config("A", "192.0.2.1", `prio = 10`),
+				ExpectError: regexp.MustCompile(`Unexpected DNS Record Priority`),
+			},
+			{
+				Config:      config("A", "192.0.2.1", `ttl = 300`),
+				ExpectError: regexp.MustCompile(`Invalid DNS Record TTL`),
+			},
+			{
+				Config:             config("mx", "mail.example.net", `prio = 10`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:             config("TLSA", "3 1 1 0a1b2c3d", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestDnsRecordResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &dnsRecordResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	upgrader := r.UpgradeState(ctx)[0]
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	prior := func(ttl, prio string) tftypes.Value {
+		return tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.String, "123"),
+			"domain":  tftypes.NewValue(tftypes.String, testAccDomain),
+			"name":    tftypes.NewValue(tftypes.String, ""),
+			"type":    tftypes.NewValue(tftypes.String, "MX"),
+			"content": tftypes.NewValue(tftypes.String, "mail.example.net"),
+			"ttl":     tftypes.NewValue(tftypes.String, ttl),
+			"prio":    tftypes.NewValue(tftypes.String, prio),
+		})
+	}
+	upgrade := func(raw tftypes.Value) (dnsRecordResourceModel, diag.Diagnostics) {
+		req := fwresource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}}
+		resp := fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}}
+		upgrader.StateUpgrader(ctx, req, &resp)
+
+		var model dnsRecordResourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+		}
+		return model, resp.Diagnostics
+	}
+
+	model, diags := upgrade(prior("0600", "10"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if model.TTL.ValueInt64() != 600 || model.Prio.ValueInt64() != 10 {
+		t.Errorf("got ttl %v and prio %v, want 600 and 10", model.TTL, model.Prio)
+	}
+	if model.ID.ValueString() != "123" || model.Type.ValueString() != "MX" || model.Content.ValueString() != "mail.example.net" {
+		t.Errorf("other attributes were not carried over: %+v", model)
+	}
+
+	model, diags = upgrade(prior("", ""))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !model.TTL.IsNull() || !model.Prio.IsNull() {
+		t.Errorf("got ttl %v and prio %v, want null", model.TTL, model.Prio)
+	}
+
+	if _, diags = upgrade(prior("ten minutes", "10")); !diags.HasError() {
+		t.Error("expected an error for a ttl that is not a number")
+	}
+}
+
+// testAccCheckNotAdopted verifies that the single record of the test domain
+// was left alone and still has the given TTL.
+func testAccCheckNotAdopted(t *testing.T, server *porkbuntest.Server, ttl string) {
+	t.Helper()
+	if n := server.RequestCount("dns/edit"); n != 0 {
+		t.Errorf("expected no record to be adopted, got %d dns/edit requests", n)
+	}
+	d, _ := server.Domain(testAccDomain)
+	if len(d.Records) != 1 || d.Records[0].TTL != ttl {
+		t.Errorf("expected the existing record to be unchanged, got %+v", d.Records)
+	}
+}
+
+func testAccDnsRecordConfig(name, recordType, content string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dns_record" "test" {
+  domain  = %q
+  name    = %q
+  type    = %q
+  content = %q
+  ttl     = 600
+}
+`, testAccDomain, name, recordType, content)
+}
+
+// testAccImportID builds an import ID by joining the given attributes of a
+// resource in state with "/".
+func testAccImportID(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		id := ""
+		for i, attribute := range attributes {
+			if i > 0 {
+				id += "/"
+			}
+			id += rs.Primary.Attributes[attribute]
+		}
+		return id, nil
+	}
+}
+
+func testAccCheckRecordContent(server *porkbuntest.Server, fqdn, content string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		d, _ := server.Domain(testAccDomain)
+		for _, rec := range d.Records {
+			if rec.Name == fqdn {
+				if rec.Content != content {
+					return fmt.Errorf("record %s has content %q, want %q", fqdn, rec.Content, content)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("record %s not found in fake API", fqdn)
+	}
+}
+
+func testAccCheckNoRecords(server *porkbuntest.Server) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		d, _ := server.Domain(testAccDomain)
+		if len(d.Records) != 0 {
+			return fmt.Errorf("expected all records to be destroyed, found %+v", d.Records)
+		}
+		return nil
+	}
+}
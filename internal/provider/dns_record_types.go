@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Porkbun normalizes records when storing them: types are upper-cased, names
+// come back fully qualified, IPv6 addresses are expanded and trailing dots are
+// trimmed. The types below treat those spellings as equal, so that the values
+// read back after an apply do not show up as a diff.
+
+var (
+	_ basetypes.StringTypable                    = recordTypeType{}
+	_ basetypes.StringValuableWithSemanticEquals = recordTypeValue{}
+	_ basetypes.StringTypable                    = recordNameType{}
+	_ basetypes.StringValuableWithSemanticEquals = recordNameValue{}
+	_ basetypes.StringTypable                    = recordContentType{}
+	_ basetypes.StringValuableWithSemanticEquals = recordContentValue{}
+)
+
+// recordTypeType is the type of a record type such as "A" or "mx". Record
+// types are compared case-insensitively.
+type recordTypeType struct {
+	basetypes.StringType
+}
+
+func (t recordTypeType) Equal(o attr.Type) bool {
+	other, ok := o.(recordTypeType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t recordTypeType) String() string {
+	return "recordTypeType"
+}
+
+func (t recordTypeType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return recordTypeValue{StringValue: in}, nil
+}
+
+func (t recordTypeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringValueFromTerraform(ctx, t, in)
+}
+
+func (t recordTypeType) ValueType(_ context.Context) attr.Value {
+	return recordTypeValue{}
+}
+
+type recordTypeValue struct {
+	basetypes.StringValue
+}
+
+func newRecordTypeValue(value string) recordTypeValue {
+	return recordTypeValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v recordTypeValue) Equal(o attr.Value) bool {
+	other, ok := o.(recordTypeValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v recordTypeValue) Type(_ context.Context) attr.Type {
+	return recordTypeType{}
+}
+
+func (v recordTypeValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(recordTypeValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", unexpectedValueTypeDetail(v, newValuable))
+		return false, diags
+	}
+	return strings.EqualFold(v.ValueString(), newValue.ValueString()), diags
+}
+
+// recordNameType is the type of a record name relative to its domain. "" and
+// "@" both stand for the domain itself, and names are compared
+// case-insensitively and without a trailing dot.
+//
+// The type does not know the domain, so it cannot tell that "www" and
+// "www.example.com" are the same name. The resource takes care of that when
+// reading a record, see recordNameMatches.
+type recordNameType struct {
+	basetypes.StringType
+}
+
+func (t recordNameType) Equal(o attr.Type) bool {
+	other, ok := o.(recordNameType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t recordNameType) String() string {
+	return "recordNameType"
+}
+
+func (t recordNameType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return recordNameValue{StringValue: in}, nil
+}
+
+func (t recordNameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringValueFromTerraform(ctx, t, in)
+}
+
+func (t recordNameType) ValueType(_ context.Context) attr.Value {
+	return recordNameValue{}
+}
+
+type recordNameValue struct {
+	basetypes.StringValue
+}
+
+func newRecordNameValue(value string) recordNameValue {
+	return recordNameValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v recordNameValue) Equal(o attr.Value) bool {
+	other, ok := o.(recordNameValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v recordNameValue) Type(_ context.Context) attr.Type {
+	return recordNameType{}
+}
+
+func (v recordNameValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(recordNameValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", unexpectedValueTypeDetail(v, newValuable))
+		return false, diags
+	}
+	return normalizeRecordName(v.ValueString()) == normalizeRecordName(newValue.ValueString()), diags
+}
+
+// recordContentType is the type of record content. IP addresses are compared
+// in their canonical form, any other content has to match exactly.
+//
+// Whether content is a hostname depends on the record type, which the type
+// does not know: "Example" and "example" are the same CNAME target but
+// different TXT values. The resource compares hostnames when reading a record,
+// see recordContentEqual.
+type recordContentType struct {
+	basetypes.StringType
+}
+
+func (t recordContentType) Equal(o attr.Type) bool {
+	other, ok := o.(recordContentType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t recordContentType) String() string {
+	return "recordContentType"
+}
+
+func (t recordContentType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return recordContentValue{StringValue: in}, nil
+}
+
+func (t recordContentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringValueFromTerraform(ctx, t, in)
+}
+
+func (t recordContentType) ValueType(_ context.Context) attr.Value {
+	return recordContentValue{}
+}
+
+type recordContentValue struct {
+	basetypes.StringValue
+}
+
+func newRecordContentValue(value string) recordContentValue {
+	return recordContentValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v recordContentValue) Equal(o attr.Value) bool {
+	other, ok := o.(recordContentValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v recordContentValue) Type(_ context.Context) attr.Type {
+	return recordContentType{}
+}
+
+func (v recordContentValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(recordContentValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", unexpectedValueTypeDetail(v, newValuable))
+		return false, diags
+	}
+	return v.ValueString() == newValue.ValueString() || addressEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// recordContentEqual reports whether a and b are the same content of a record
+// of recordType as far as Porkbun is concerned. Only hostnames are compared
+// case-insensitively and without a trailing dot, so a changed TXT value is
+// never mistaken for the same content.
+func recordContentEqual(recordType, a, b string) bool {
+	if a == b {
+		return true
+	}
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		return addressEqual(a, b)
+	case "CNAME", "ALIAS", "MX", "NS":
+		return hostnameEqual(a, b)
+	case "SRV":
+		// weight port target
+		fieldsA, fieldsB := strings.Fields(a), strings.Fields(b)
+		if len(fieldsA) != 3 || len(fieldsB) != 3 {
+			return false
+		}
+		return fieldsA[0] == fieldsB[0] && fieldsA[1] == fieldsB[1] && hostnameEqual(fieldsA[2], fieldsB[2])
+	default:
+		return false
+	}
+}
+
+// addressEqual reports whether a and b are the same IP address.
+func addressEqual(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	return errA == nil && errB == nil && addrA == addrB
+}
+
+// hostnameEqual reports whether a and b are the same hostname.
+func hostnameEqual(a, b string) bool {
+	return isHostname(a) && isHostname(b) && strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// normalizeRecordName lower-cases name, drops a trailing dot and maps "@" to
+// "".
+func normalizeRecordName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+// relativeRecordName returns name relative to domain, accepting "", "@",
+// relative and fully-qualified names. This is the form Porkbun expects when
+// creating or looking up records.
+func relativeRecordName(name, domain string) string {
+	name = normalizeRecordName(name)
+	domain = strings.ToLower(domain)
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+// recordNameMatches reports whether name, in any of the forms accepted by
+// relativeRecordName, refers to the fully-qualified fqdn in domain.
+func recordNameMatches(name, fqdn, domain string) bool {
+	return relativeRecordName(name, domain) == relativeRecordName(fqdn, domain)
+}
+
+func stringValueFromTerraform(ctx context.Context, t basetypes.StringTypable, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := basetypes.StringType{}.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func unexpectedValueTypeDetail(expected, got attr.Value) string {
+	return fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. "+
+		"Please report this to the provider developers.\n\nExpected Value Type: %T\nGot Value Type: %T", expected, got)
+}
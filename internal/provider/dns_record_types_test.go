@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+func TestRecordContentEqual(t *testing.T) {
+	tests := []struct {
+		recordType string
+		a, b       string
+		want       bool
+	}{
+		{"A", "192.0.2.1", "192.0.2.1", true},
+		{"AAAA", "2001:DB8::1", "2001:0db8:0000:0000:0000:0000:0000:0001", true},
+		{"AAAA", "2001:db8::1", "2001:db8::2", false},
+		{"CNAME", "Target.Example.net.", "target.example.net", true},
+		{"MX", "mail.example.net.", "mail.example.net", true},
+		{"SRV", "5 5060 SIP.Example.net.", "5 5060 sip.example.net", true},
+		{"SRV", "5 5060 sip.example.net", "5 5061 sip.example.net", false},
+		{"TXT", "v=DKIM1; p=ABC", "v=DKIM1; p=abc", false},
+		{"TXT", "Verification-Token", "verification-token", false},
+		{"TXT", "example.net.", "example.net", false},
+		{"TXT", "hello", "hello", true},
+	}
+
+	for _, tt := range tests {
+		if got := recordContentEqual(tt.recordType, tt.a, tt.b); got != tt.want {
+			t.Errorf("recordContentEqual(%q, %q, %q) = %v, want %v", tt.recordType, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
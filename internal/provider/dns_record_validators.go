@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// minRecordTTL is the lowest TTL Porkbun accepts. Lower values are
+	// silently raised by the API, which would show up as drift.
+	minRecordTTL = 600
+	// maxRecordTTL is the highest TTL allowed by RFC 2181.
+	maxRecordTTL  = 2147483647
+	maxRecordPrio = 65535
+)
+
+// supportedRecordTypes are the record types Porkbun can manage.
+var supportedRecordTypes = []string{"A", "AAAA", "CNAME", "ALIAS", "MX", "TXT", "NS", "SRV", "TLSA", "CAA", "HTTPS", "SVCB", "SSHFP"}
+
+var (
+	hostnameLabelPattern = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?$`)
+	caaTagPattern        = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// recordContentValidators check the content of a record of the given type
+// and return a description of the problem, or "" if it is fine.
+var recordContentValidators = map[string]func(content string) string{
+	"A": func(content string) string {
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is4() {
+			return "must be an IPv4 address, e.g. 192.0.2.1"
+		}
+		return ""
+	},
+	"AAAA": func(content string) string {
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is6() || addr.Is4In6() || addr.Zone() != "" {
+			return "must be an IPv6 address, e.g. 2001:db8::1"
+		}
+		return ""
+	},
+	"CNAME": validateHostnameContent,
+	"ALIAS": validateHostnameContent,
+	"NS":    validateHostnameContent,
+	"MX": func(content string) string {
+		if msg := validateHostnameContent(content); msg != "" {
+			return msg + "; the priority belongs in prio"
+		}
+		return ""
+	},
+	"TXT": func(content string) string {
+		if strings.TrimSpace(content) == "" {
+			return "must not be empty"
+		}
+		return ""
+	},
+	"SRV": func(content string) string {
+		fields := strings.Fields(content)
+		if len(fields) != 3 || !isUint(fields[0], 65535) || !isUint(fields[1], 65535) || !isTarget(fields[2]) {
+			return `must be "weight port target", e.g. "5 5060 sip.example.com"; the priority belongs in prio`
+		}
+		return ""
+	},
+	"TLSA": func(content string) string {
+		fields := strings.Fields(content)
+		if len(fields) != 4 || !isUint(fields[0], 3) || !isUint(fields[1], 1) || !isUint(fields[2], 2) || !isHex(fields[3]) {
+			return `must be "usage selector matching-type data" with usage 0-3, selector 0-1, matching type 0-2 and hex data`
+		}
+		return ""
+	},
+	"CAA": func(content string) string {
+		fields := strings.SplitN(content, " ", 3)
+		if len(fields) != 3 || !isUint(fields[0], 255) || !caaTagPattern.MatchString(fields[1]) || fields[2] == "" {
+			return `must be "flags tag value", e.g. "0 issue letsencrypt.org"`
+		}
+		return ""
+	},
+	"HTTPS": validateServiceBindingContent,
+	"SVCB":  validateServiceBindingContent,
+	"SSHFP": func(content string) string {
+		fields := strings.Fields(content)
+		if len(fields) != 3 || !isUint(fields[0], 255) || !isUint(fields[1], 255) || !isHex(fields[2]) {
+			return `must be "algorithm type fingerprint" with a hex fingerprint, e.g. "4 2 123456789abcdef6"`
+		}
+		return ""
+	},
+}
+
+func validateHostnameContent(content string) string {
+	if !isHostname(content) {
+		return "must be a hostname, e.g. host.example.com"
+	}
+	return ""
+}
+
+func validateServiceBindingContent(content string) string {
+	fields := strings.Fields(content)
+	if len(fields) < 2 || !isUint(fields[0], 65535) || !isTarget(fields[1]) {
+		return `must be "priority target [params...]", e.g. "1 . alpn=h2"`
+	}
+	for _, param := range fields[2:] {
+		if key, _, _ := strings.Cut(param, "="); key == "" {
+			return fmt.Sprintf("has an invalid parameter %q", param)
+		}
+	}
+	return ""
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTarget reports whether s is a hostname or ".", which SRV, HTTPS and SVCB
+// records use for "no target" or "the owner name".
+func isTarget(s string) bool {
+	return s == "." || isHostname(s)
+}
+
+func isUint(s string, max uint64) bool {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return err == nil && n <= max
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return s != "" && err == nil
+}
+
+func dnsRecordConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		dnsRecordTypeValidator{},
+		dnsRecordContentValidator{},
+		dnsRecordPrioValidator{},
+		dnsRecordTTLValidator{},
+	}
+}
+
+// dnsRecordTypeValidator rejects record types Porkbun does not support.
+type dnsRecordTypeValidator struct{}
+
+func (v dnsRecordTypeValidator) Description(_ context.Context) string {
+	return "type must be one of " + strings.Join(supportedRecordTypes, ", ")
+}
+
+func (v dnsRecordTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType recordTypeValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if recordType.IsNull() || recordType.IsUnknown() {
+		return
+	}
+
+	if _, ok := recordContentValidators[strings.ToUpper(recordType.ValueString())]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Unsupported DNS Record Type",
+			fmt.Sprintf("Porkbun does not support %q records. Supported types are %s.", recordType.ValueString(), strings.Join(supportedRecordTypes, ", ")),
+		)
+	}
+}
+
+// dnsRecordContentValidator checks the syntax of content for the record type.
+type dnsRecordContentValidator struct{}
+
+func (v dnsRecordContentValidator) Description(_ context.Context) string {
+	return "content must be valid for the record type"
+}
+
+func (v dnsRecordContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordContentValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType recordTypeValue
+	var content recordContentValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	if recordType.IsNull() || recordType.IsUnknown() || content.IsNull() || content.IsUnknown() {
+		return
+	}
+
+	typeName := strings.ToUpper(recordType.ValueString())
+	validate, ok := recordContentValidators[typeName]
+	if !ok {
+		return
+	}
+	if msg := validate(content.ValueString()); msg != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid DNS Record Content",
+			fmt.Sprintf("The content of a %s record %s, got: %q", typeName, msg, content.ValueString()),
+		)
+	}
+}
+
+// dnsRecordPrioValidator requires prio for MX and SRV records and forbids it
+// for all other types, where Porkbun ignores it.
+type dnsRecordPrioValidator struct{}
+
+func (v dnsRecordPrioValidator) Description(_ context.Context) string {
+	return "prio is required for MX and SRV records and not allowed otherwise"
+}
+
+func (v dnsRecordPrioValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordPrioValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType recordTypeValue
+	var prio types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prio"), &prio)...)
+	if recordType.IsNull() || recordType.IsUnknown() || prio.IsUnknown() {
+		return
+	}
+
+	typeName := strings.ToUpper(recordType.ValueString())
+	switch {
+	case recordTypeUsesPrio(typeName):
+		if prio.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prio"),
+				"Missing DNS Record Priority",
+				fmt.Sprintf("%s records require prio to be set.", typeName),
+			)
+			return
+		}
+		if n := prio.ValueInt64(); n < 0 || n > maxRecordPrio {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prio"),
+				"Invalid DNS Record Priority",
+				fmt.Sprintf("prio must be between 0 and %d, got: %d", maxRecordPrio, n),
+			)
+		}
+	case !prio.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prio"),
+			"Unexpected DNS Record Priority",
+			fmt.Sprintf("prio is only used by MX and SRV records and must not be set for %s records.", typeName),
+		)
+	}
+}
+
+// recordTypeUsesPrio reports whether records of recordType have a priority.
+func recordTypeUsesPrio(recordType string) bool {
+	recordType = strings.ToUpper(recordType)
+	return recordType == "MX" || recordType == "SRV"
+}
+
+// dnsRecordTTLValidator enforces Porkbun's minimum TTL.
+type dnsRecordTTLValidator struct{}
+
+func (v dnsRecordTTLValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("ttl must be between %d and %d seconds", minRecordTTL, maxRecordTTL)
+}
+
+func (v dnsRecordTTLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordTTLValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	if ttl.IsNull() || ttl.IsUnknown() {
+		return
+	}
+
+	if n := ttl.ValueInt64(); n < minRecordTTL || n > maxRecordTTL {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ttl"),
+			"Invalid DNS Record TTL",
+			fmt.Sprintf("ttl must be between %d and %d seconds, got: %d", minRecordTTL, maxRecordTTL, n),
+		)
+	}
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -34,11 +33,12 @@ type dnsRecordsDataSourceModel struct {
 func recordAttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"id":      types.StringType,
-		"name":    types.StringType,
-		"type":    types.StringType,
-		"content": types.StringType,
-		"ttl":     types.StringType,
-		"prio":    types.StringType,
+		"name":    recordNameType{},
+		"type":    recordTypeType{},
+		"content": recordContentType{},
+		"ttl":     types.Int64Type,
+		"prio":    types.Int64Type,
+		"notes":   types.StringType,
 	}
 }
 
@@ -64,11 +64,12 @@ func (d *dnsRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id":      schema.StringAttribute{Computed: true},
-						"name":    schema.StringAttribute{Computed: true},
-						"type":    schema.StringAttribute{Computed: true},
-						"content": schema.StringAttribute{Computed: true},
-						"ttl":     schema.StringAttribute{Computed: true},
-						"prio":    schema.StringAttribute{Computed: true},
+						"name":    schema.StringAttribute{CustomType: recordNameType{}, Computed: true},
+						"type":    schema.StringAttribute{CustomType: recordTypeType{}, Computed: true},
+						"content": schema.StringAttribute{CustomType: recordContentType{}, Computed: true},
+						"ttl":     schema.Int64Attribute{Computed: true},
+						"prio":    schema.Int64Attribute{Computed: true},
+						"notes":   schema.StringAttribute{Computed: true},
 					},
 				},
 			},
@@ -96,7 +97,7 @@ func (d *dnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	domain := config.Domain.ValueString()
-	records, err := d.client.RetrieveRecords(domain)
+	records, err := d.client.RetrieveRecords(ctx, domain)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Abrufen der DNS-Einträge", fmt.Sprintf("Konnte Einträge für Domain %s nicht abrufen: %s", domain, err.Error()))
 		return
@@ -108,21 +109,16 @@ func (d *dnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	var recordModels []attr.Value
 	for _, record := range records {
-		recordName := record.Name
-		normalizedName := strings.TrimSuffix(recordName, "."+domain)
-		if normalizedName == domain {
-			normalizedName = ""
-		}
-
 		recordModels = append(recordModels, types.ObjectValueMust(
 			recordAttributeTypes(),
 			map[string]attr.Value{
 				"id":      types.StringValue(record.ID),
-				"name":    types.StringValue(normalizedName),
-				"type":    types.StringValue(record.Type),
-				"content": types.StringValue(record.Content),
-				"ttl":     types.StringValue(record.TTL),
-				"prio":    types.StringValue(record.Prio),
+				"name":    newRecordNameValue(relativeRecordName(record.Name, domain)),
+				"type":    newRecordTypeValue(record.Type),
+				"content": newRecordContentValue(record.Content),
+				"ttl":     types.Int64Value(int64(record.TTL)),
+				"prio":    types.Int64Value(int64(record.Prio)),
+				"notes":   types.StringValue(record.Notes),
 			},
 		))
 	}
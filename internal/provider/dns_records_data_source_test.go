@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDnsRecordsDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Records = []porkbuntest.Record{
+			{ID: "1", Name: testAccDomain, Type: "A", Content: "192.0.2.1", TTL: "600", Prio: "0"},
+			{ID: "2", Name: "www." + testAccDomain, Type: "CNAME", Content: testAccDomain, TTL: "600", Prio: "0"},
+			{ID: "3", Name: testAccDomain, Type: "MX", Content: "mail.example.net", TTL: "3600", Prio: "10"},
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_dns_records" "test" {
+  domain = %q
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "id", testAccDomain),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.#", "3"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.0.name", ""),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.1.name", "www"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.1.type", "CNAME"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.2.prio", "10"),
+					resource.TestCheckResourceAttr("data.porkbun_dns_records.test", "records.2.ttl", "3600"),
+				),
+			},
+		},
+	})
+}
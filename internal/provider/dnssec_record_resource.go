@@ -3,6 +3,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -50,11 +51,10 @@ func (r *dnssecRecordResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = schema.Schema{
 		Description: "Verwaltet einen DNSSEC DS (Delegation Signer) Eintrag für eine Domain.",
 		Attributes: map[string]schema.Attribute{
+			// Die ID setzt sich aus den DS-Feldern zusammen und ändert sich
+			// bei jedem Update, daher kein UseStateForUnknown.
 			"id": schema.StringAttribute{
 				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
 			},
 			"domain": schema.StringAttribute{
 				Description: "Der Domainname.",
@@ -75,12 +75,12 @@ func (r *dnssecRecordResource) Configure(ctx context.Context, req resource.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*porkbun.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
-		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *porkbun.Client, erhalten: %T", req.ProviderData))
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *provider.resourceData, erhalten: %T", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func (r *dnssecRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -97,7 +97,7 @@ func (r *dnssecRecordResource) Create(ctx context.Context, req resource.CreateRe
 		Digest:     plan.Digest.ValueString(),
 	}
 
-	err := r.client.AddDnssecRecord(plan.Domain.ValueString(), record)
+	err := r.client.AddDnssecRecord(ctx, plan.Domain.ValueString(), record)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Erstellen des DNSSEC-Eintrags", err.Error())
 		return
@@ -122,7 +122,7 @@ func (r *dnssecRecordResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	domain := state.Domain.ValueString()
-	allRecords, err := r.client.GetDnssecRecords(domain)
+	allRecords, err := r.client.GetDnssecRecords(ctx, domain)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Lesen der DNSSEC-Einträge", err.Error())
 		return
@@ -167,7 +167,7 @@ func (r *dnssecRecordResource) Update(ctx context.Context, req resource.UpdateRe
 		KeyTag:     state.KeyTag.ValueString(),
 		Digest:     state.Digest.ValueString(),
 	}
-	err := r.client.DeleteDnssecRecord(state.Domain.ValueString(), oldRecord)
+	err := r.client.DeleteDnssecRecord(ctx, state.Domain.ValueString(), oldRecord)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Aktualisieren (Löschen) des alten DNSSEC-Eintrags", err.Error())
 		return
@@ -179,7 +179,7 @@ func (r *dnssecRecordResource) Update(ctx context.Context, req resource.UpdateRe
 		KeyTag:     plan.KeyTag.ValueString(),
 		Digest:     plan.Digest.ValueString(),
 	}
-	err = r.client.AddDnssecRecord(plan.Domain.ValueString(), newRecord)
+	err = r.client.AddDnssecRecord(ctx, plan.Domain.ValueString(), newRecord)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Aktualisieren (Hinzufügen) des neuen DNSSEC-Eintrags", err.Error())
 		return
@@ -210,8 +210,8 @@ func (r *dnssecRecordResource) Delete(ctx context.Context, req resource.DeleteRe
 		Digest:     state.Digest.ValueString(),
 	}
 
-	err := r.client.DeleteDnssecRecord(state.Domain.ValueString(), record)
-	if err != nil {
+	err := r.client.DeleteDnssecRecord(ctx, state.Domain.ValueString(), record)
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
 		resp.Diagnostics.AddError("Fehler beim Löschen des DNSSEC-Eintrags", err.Error())
 		return
 	}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDnssecRecordResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckDnssecCount(server, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnssecRecordConfig("12345", "ABCDEF0123456789"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_dnssec_record.test", "id", testAccDomain+"/12345/13/2/ABCDEF0123456789"),
+					testAccCheckDnssecCount(server, 1),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      "porkbun_dnssec_record.test",
+				ImportState:       true,
+				ImportStateId:     testAccDomain + "/12345/13/2/ABCDEF0123456789",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccDnssecRecordConfig("54321", "9876543210FEDCBA"),
+				Check: func(*terraform.State) error {
+					d, _ := server.Domain(testAccDomain)
+					if len(d.Dnssec) != 1 || d.Dnssec[0].KeyTag != "54321" {
+						return fmt.Errorf("unexpected DS records %+v", d.Dnssec)
+					}
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Dnssec = nil
+					})
+				},
+				Config: testAccDnssecRecordConfig("54321", "9876543210FEDCBA"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_dnssec_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckDnssecCount(server, 1),
+			},
+		},
+	})
+}
+
+func testAccDnssecRecordConfig(keyTag, digest string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_dnssec_record" "test" {
+  domain      = %q
+  key_tag     = %q
+  algorithm   = "13"
+  digest_type = "2"
+  digest      = %q
+}
+`, testAccDomain, keyTag, digest)
+}
+
+func testAccCheckDnssecCount(server *porkbuntest.Server, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		d, _ := server.Domain(testAccDomain)
+		if len(d.Dnssec) != want {
+			return fmt.Errorf("expected %d DS records, found %+v", want, d.Dnssec)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &domainAvailabilityDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainAvailabilityDataSource{}
+)
+
+func NewDomainAvailabilityDataSource() datasource.DataSource {
+	return &domainAvailabilityDataSource{}
+}
+
+type domainAvailabilityDataSource struct {
+	client *porkbun.Client
+}
+
+type domainAvailabilityDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Domain         types.String `tfsdk:"domain"`
+	Available      types.Bool   `tfsdk:"available"`
+	Premium        types.Bool   `tfsdk:"premium"`
+	FirstYearPromo types.Bool   `tfsdk:"first_year_promo"`
+	Price          types.String `tfsdk:"price"`
+	RegularPrice   types.String `tfsdk:"regular_price"`
+	RenewalPrice   types.String `tfsdk:"renewal_price"`
+	RateLimit      types.Object `tfsdk:"rate_limit"`
+}
+
+func rateLimitAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"limit":          types.Int64Type,
+		"used":           types.Int64Type,
+		"window_seconds": types.Int64Type,
+		"description":    types.StringType,
+	}
+}
+
+func (d *domainAvailabilityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_availability"
+}
+
+func (d *domainAvailabilityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Prüft, ob eine Domain registriert werden kann und zu welchem Preis. Porkbun erlaubt nur wenige Prüfungen pro Minute.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Wird auf den Domainnamen gesetzt.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der zu prüfende Domainname (z. B. example.com).",
+				Required:    true,
+			},
+			"available": schema.BoolAttribute{
+				Description: "Ob die Domain registriert werden kann.",
+				Computed:    true,
+			},
+			"premium": schema.BoolAttribute{
+				Description: "Ob es sich um eine Premium-Domain mit Sonderpreis handelt.",
+				Computed:    true,
+			},
+			"first_year_promo": schema.BoolAttribute{
+				Description: "Ob für das erste Jahr ein Aktionspreis gilt.",
+				Computed:    true,
+			},
+			"price": schema.StringAttribute{
+				Description: "Der Preis für das erste Jahr in USD.",
+				Computed:    true,
+			},
+			"regular_price": schema.StringAttribute{
+				Description: "Der reguläre Registrierungspreis ohne Aktion in USD.",
+				Computed:    true,
+			},
+			"renewal_price": schema.StringAttribute{
+				Description: "Der Preis für eine Verlängerung um ein Jahr in USD.",
+				Computed:    true,
+			},
+			"rate_limit": schema.SingleNestedAttribute{
+				Description: "Das Kontingent für Verfügbarkeitsprüfungen, wie von Porkbun gemeldet.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"limit": schema.Int64Attribute{
+						Description: "Die Anzahl erlaubter Prüfungen pro Zeitfenster.",
+						Computed:    true,
+					},
+					"used": schema.Int64Attribute{
+						Description: "Die Anzahl bereits verbrauchter Prüfungen im aktuellen Zeitfenster.",
+						Computed:    true,
+					},
+					"window_seconds": schema.Int64Attribute{
+						Description: "Die Länge des Zeitfensters in Sekunden.",
+						Computed:    true,
+					},
+					"description": schema.StringAttribute{
+						Description: "Die Beschreibung des Kontingents im Klartext.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *domainAvailabilityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Data Source", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *domainAvailabilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config domainAvailabilityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := strings.ToLower(config.Domain.ValueString())
+	check, err := d.client.CheckDomain(ctx, domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler bei der Verfügbarkeitsprüfung", fmt.Sprintf("Konnte die Verfügbarkeit von %s nicht prüfen: %s", domain, err.Error()))
+		return
+	}
+	availability := check.Response
+
+	state := config
+	state.ID = types.StringValue(domain)
+	state.Available = types.BoolValue(bool(availability.Avail))
+	state.Premium = types.BoolValue(bool(availability.Premium))
+	state.FirstYearPromo = types.BoolValue(bool(availability.FirstYearPromo))
+	state.Price = types.StringValue(availability.Price)
+	state.RegularPrice = types.StringValue(availability.RegularPrice)
+	state.RenewalPrice = types.StringValue(availability.Additional.Renewal.Price)
+
+	var diags diag.Diagnostics
+	state.RateLimit, diags = types.ObjectValue(rateLimitAttributeTypes(), map[string]attr.Value{
+		"limit":          types.Int64Value(int64(check.Limits.Limit)),
+		"used":           types.Int64Value(int64(check.Limits.Used)),
+		"window_seconds": types.Int64Value(int64(check.Limits.TTL)),
+		"description":    types.StringValue(check.Limits.NaturalLanguage),
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainAvailabilityDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.SetAvailability("fancy.net", true, "2500.00")
+	server.SetAvailability("taken.org", false, "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_domain_availability" "free" {
+  domain = "Available.COM"
+}
+
+data "porkbun_domain_availability" "premium" {
+  domain = "fancy.net"
+}
+
+data "porkbun_domain_availability" "taken" {
+  domain = "taken.org"
+}
+
+data "porkbun_domain_availability" "own" {
+  domain = %q
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "id", "available.com"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "available", "true"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "premium", "false"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "price", "11.08"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "renewal_price", "11.08"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "rate_limit.limit", "10"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.free", "rate_limit.window_seconds", "60"),
+					resource.TestCheckResourceAttrSet("data.porkbun_domain_availability.free", "rate_limit.used"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.premium", "premium", "true"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.premium", "price", "2500.00"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.premium", "renewal_price", "12.52"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.taken", "available", "false"),
+					resource.TestCheckResourceAttr("data.porkbun_domain_availability.own", "available", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &domainDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainDataSource{}
+)
+
+func NewDomainDataSource() datasource.DataSource {
+	return &domainDataSource{}
+}
+
+type domainDataSource struct {
+	client *porkbun.Client
+}
+
+type domainDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Domain          types.String `tfsdk:"domain"`
+	Status          types.String `tfsdk:"status"`
+	Tld             types.String `tfsdk:"tld"`
+	CreateDate      types.String `tfsdk:"create_date"`
+	ExpireDate      types.String `tfsdk:"expire_date"`
+	DaysUntilExpiry types.Int64  `tfsdk:"days_until_expiry"`
+	SecurityLock    types.Bool   `tfsdk:"security_lock"`
+	WhoisPrivacy    types.Bool   `tfsdk:"whois_privacy"`
+	AutoRenew       types.Bool   `tfsdk:"auto_renew"`
+	Labels          types.List   `tfsdk:"labels"`
+	Nameservers     types.List   `tfsdk:"nameservers"`
+	GlueHosts       types.Map    `tfsdk:"glue_hosts"`
+}
+
+func (d *domainDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ruft die Details einer einzelnen Domain im Porkbun-Konto ab.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Wird auf den Domainnamen gesetzt.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der Domainname (z. B. example.com).",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Der aktuelle Status der Domain.",
+				Computed:    true,
+			},
+			"tld": schema.StringAttribute{
+				Description: "Die TLD der Domain.",
+				Computed:    true,
+			},
+			"create_date": schema.StringAttribute{
+				Description: "Das Registrierungsdatum im RFC3339-Format.",
+				Computed:    true,
+			},
+			"expire_date": schema.StringAttribute{
+				Description: "Das Ablaufdatum im RFC3339-Format.",
+				Computed:    true,
+			},
+			"days_until_expiry": schema.Int64Attribute{
+				Description: "Die Anzahl ganzer Tage bis zum Ablauf. Negativ, wenn die Domain bereits abgelaufen ist.",
+				Computed:    true,
+			},
+			"security_lock": schema.BoolAttribute{
+				Description: "Ob die Registrar-Sperre aktiv ist.",
+				Computed:    true,
+			},
+			"whois_privacy": schema.BoolAttribute{
+				Description: "Ob WHOIS-Privacy aktiv ist.",
+				Computed:    true,
+			},
+			"auto_renew": schema.BoolAttribute{
+				Description: "Ob die automatische Verlängerung aktiv ist.",
+				Computed:    true,
+			},
+			"labels": schema.ListAttribute{
+				Description: "Die Titel der Konto-Labels, die der Domain zugewiesen sind.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Die aktuell gesetzten Nameserver.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"glue_hosts": schema.MapAttribute{
+				Description: "Die Glue-Records der Domain, vom Hostnamen (z. B. `ns1`) auf seine IP-Adressen.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *domainDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Data Source", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config domainDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domainName := config.Domain.ValueString()
+
+	domainListings, err := d.client.ListAllDomains(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
+		return
+	}
+
+	var domain *porkbun.DomainListing
+	for i := range domainListings {
+		if strings.EqualFold(domainListings[i].Domain, domainName) {
+			domain = &domainListings[i]
+			break
+		}
+	}
+	if domain == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Domain nicht gefunden",
+			fmt.Sprintf("Die Domain %s ist nicht im Porkbun-Konto vorhanden.", domainName),
+		)
+		return
+	}
+	// Ab hier die Schreibweise aus der Domain-Liste verwenden.
+	domainName = domain.Domain
+
+	nameservers, err := d.client.GetNameservers(ctx, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Nameserver", fmt.Sprintf("Konnte Nameserver für %s nicht abrufen: %s", domainName, err.Error()))
+		return
+	}
+
+	glue, err := d.client.GetGlueRecords(ctx, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Glue-Records", fmt.Sprintf("Konnte Glue-Records für %s nicht abrufen: %s", domainName, err.Error()))
+		return
+	}
+
+	state := config
+	state.ID = types.StringValue(domainName)
+	state.Status = types.StringValue(domain.Status)
+	state.Tld = types.StringValue(domain.Tld)
+	state.SecurityLock = types.BoolValue(bool(domain.SecurityLock))
+	state.WhoisPrivacy = types.BoolValue(bool(domain.WhoisPrivacy))
+	state.AutoRenew = types.BoolValue(bool(domain.AutoRenew))
+
+	state.CreateDate, state.ExpireDate, state.DaysUntilExpiry = types.StringNull(), types.StringNull(), types.Int64Null()
+	if !domain.CreateDate.IsZero() {
+		state.CreateDate = types.StringValue(domain.CreateDate.Format(time.RFC3339))
+	}
+	if !domain.ExpireDate.IsZero() {
+		state.ExpireDate = types.StringValue(domain.ExpireDate.Format(time.RFC3339))
+		state.DaysUntilExpiry = types.Int64Value(int64(math.Floor(time.Until(domain.ExpireDate.Time).Hours() / 24)))
+	}
+
+	labels := make([]string, 0, len(domain.Labels))
+	for _, label := range domain.Labels {
+		labels = append(labels, label.Title)
+	}
+
+	var diags diag.Diagnostics
+	state.Labels, diags = types.ListValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diags...)
+	state.Nameservers, diags = types.ListValueFrom(ctx, types.StringType, nameservers)
+	resp.Diagnostics.Append(diags...)
+
+	glueHosts := make(map[string]attr.Value, len(glue))
+	for host, hostIPs := range glue {
+		ips, diags := types.ListValueFrom(ctx, types.StringType, hostIPs)
+		resp.Diagnostics.Append(diags...)
+		glueHosts[host] = ips
+	}
+	state.GlueHosts, diags = types.MapValue(types.ListType{ElemType: types.StringType}, glueHosts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
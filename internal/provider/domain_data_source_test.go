@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	expires := time.Now().UTC().AddDate(0, 0, 45).Add(time.Hour).Truncate(time.Second)
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.CreateDate = "2021-03-04 05:06:07"
+		d.ExpireDate = expires.Format("2006-01-02 15:04:05")
+		d.SecurityLock = true
+		d.AutoRenew = true
+		d.Labels = []porkbuntest.Label{{ID: "1", Title: "prod"}}
+		d.Glue = map[string][]string{"ns1": {"192.0.2.53", "2001:db8::53"}}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `data "porkbun_domain" "test" { domain = "example.com" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "id", "example.com"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "tld", "com"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "create_date", "2021-03-04T05:06:07Z"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "expire_date", expires.Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "days_until_expiry", "45"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "auto_renew", "true"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "labels.0", "prod"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "nameservers.#", "4"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "nameservers.0", porkbuntest.DefaultNameservers[0]),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "glue_hosts.%", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "glue_hosts.ns1.#", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.test", "glue_hosts.ns1.1", "2001:db8::53"),
+				),
+			},
+			{
+				Config: testAccProviderConfig() + `data "porkbun_domain" "upper" { domain = "Example.COM" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_domain.upper", "id", "example.com"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.upper", "domain", "Example.COM"),
+					resource.TestCheckResourceAttr("data.porkbun_domain.upper", "status", "ACTIVE"),
+				),
+			},
+			{
+				Config:      testAccProviderConfig() + `data "porkbun_domain" "missing" { domain = "not-mine.com" }`,
+				ExpectError: regexp.MustCompile(`nicht im Porkbun-Konto vorhanden`),
+			},
+		},
+	})
+}
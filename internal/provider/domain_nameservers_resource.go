@@ -2,9 +2,9 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -41,6 +41,33 @@ type domainNameserversResourceModel struct {
 	Nameservers types.List   `tfsdk:"nameservers"`
 }
 
+// orderLike gibt current zurück, wenn remote dieselben Elemente enthält, damit
+// eine abweichende Reihenfolge der API keinen Diff erzeugt. Andernfalls wird
+// remote sortiert zurückgegeben.
+func orderLike(remote, current []string) []string {
+	if len(remote) == len(current) {
+		counts := make(map[string]int, len(current))
+		for _, v := range current {
+			counts[v]++
+		}
+		same := true
+		for _, v := range remote {
+			if counts[v] == 0 {
+				same = false
+				break
+			}
+			counts[v]--
+		}
+		if same {
+			return current
+		}
+	}
+
+	sorted := append([]string(nil), remote...)
+	sort.Strings(sorted)
+	return sorted
+}
+
 func (r *domainNameserversResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_domain_nameservers"
 }
@@ -75,12 +102,12 @@ func (r *domainNameserversResource) Configure(ctx context.Context, req resource.
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*porkbun.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
-		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *porkbun.Client, erhalten: %T", req.ProviderData))
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *provider.resourceData, erhalten: %T", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func (r *domainNameserversResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -96,7 +123,7 @@ func (r *domainNameserversResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	err := r.client.UpdateNameservers(plan.Domain.ValueString(), ns)
+	err := r.client.UpdateNameservers(ctx, plan.Domain.ValueString(), ns)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Erstellen der Nameserver", "Konnte Nameserver nicht aktualisieren: "+err.Error())
 		return
@@ -115,9 +142,9 @@ func (r *domainNameserversResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	domain := state.Domain.ValueString()
-	foundNs, err := r.client.GetNameservers(domain)
+	foundNs, err := r.client.GetNameservers(ctx, domain)
 	if err != nil {
-		if strings.Contains(err.Error(), "Domain not found") || strings.Contains(err.Error(), "Domain does not exist") {
+		if errors.Is(err, porkbun.ErrNotFound) {
 			tflog.Warn(ctx, "Domain nicht gefunden, wird aus dem State entfernt.", map[string]any{"domain": domain})
 			resp.State.RemoveResource(ctx)
 			return
@@ -133,10 +160,14 @@ func (r *domainNameserversResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	sort.Strings(foundNs)
+	var currentNs []string
+	resp.Diagnostics.Append(state.Nameservers.ElementsAs(ctx, &currentNs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	state.ID = types.StringValue(domain)
-	state.Nameservers, resp.Diagnostics = types.ListValueFrom(ctx, types.StringType, foundNs)
+	state.Nameservers, resp.Diagnostics = types.ListValueFrom(ctx, types.StringType, orderLike(foundNs, currentNs))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -154,7 +185,7 @@ func (r *domainNameserversResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	err := r.client.UpdateNameservers(plan.Domain.ValueString(), ns)
+	err := r.client.UpdateNameservers(ctx, plan.Domain.ValueString(), ns)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Aktualisieren der Nameserver", "Konnte Nameserver nicht aktualisieren: "+err.Error())
 		return
@@ -171,7 +202,7 @@ func (r *domainNameserversResource) Delete(ctx context.Context, req resource.Del
 	}
 
 	tflog.Info(ctx, "Setze Nameserver auf Porkbun-Standard zurück", map[string]any{"domain": state.Domain.ValueString()})
-	err := r.client.UpdateNameservers(state.Domain.ValueString(), defaultPorkbunNameservers)
+	err := r.client.UpdateNameservers(ctx, state.Domain.ValueString(), defaultPorkbunNameservers)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Zurücksetzen der Nameserver", "Konnte Nameserver nicht auf Standard zurücksetzen: "+err.Error())
 		return
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDomainNameserversResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy:             testAccCheckNameservers(server, porkbuntest.DefaultNameservers...),
+		Steps: []resource.TestStep{
+			{
+				// Deliberately unsorted to catch ordering drift.
+				Config: testAccDomainNameserversConfig("ns2.example.net", "ns1.example.net"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_domain_nameservers.test", "id", testAccDomain),
+					resource.TestCheckResourceAttr("porkbun_domain_nameservers.test", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr("porkbun_domain_nameservers.test", "nameservers.0", "ns2.example.net"),
+					testAccCheckNameservers(server, "ns2.example.net", "ns1.example.net"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      "porkbun_domain_nameservers.test",
+				ImportState:       true,
+				ImportStateId:     testAccDomain,
+				ImportStateVerify: true,
+				// The API does not guarantee an order, so import yields the
+				// sorted list.
+				ImportStateVerifyIgnore: []string{"nameservers"},
+			},
+			{
+				Config: testAccDomainNameserversConfig("ns1.example.net", "ns2.example.net", "ns3.example.net"),
+				Check:  testAccCheckNameservers(server, "ns1.example.net", "ns2.example.net", "ns3.example.net"),
+			},
+			{
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.Nameservers = []string{"ns.changed-by-hand.net"}
+					})
+				},
+				Config: testAccDomainNameserversConfig("ns1.example.net", "ns2.example.net", "ns3.example.net"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_domain_nameservers.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckNameservers(server, "ns1.example.net", "ns2.example.net", "ns3.example.net"),
+			},
+		},
+	})
+}
+
+func testAccDomainNameserversConfig(nameservers ...string) string {
+	list := ""
+	for _, ns := range nameservers {
+		list += fmt.Sprintf("%q, ", ns)
+	}
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_domain_nameservers" "test" {
+  domain      = %q
+  nameservers = [%s]
+}
+`, testAccDomain, list)
+}
+
+func testAccCheckNameservers(server *porkbuntest.Server, want ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		d, _ := server.Domain(testAccDomain)
+		if !reflect.DeepEqual(d.Nameservers, want) {
+			return fmt.Errorf("nameservers are %v, want %v", d.Nameservers, want)
+		}
+		return nil
+	}
+}
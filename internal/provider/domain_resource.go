@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &domainResource{}
+var _ resource.ResourceWithConfigure = &domainResource{}
+var _ resource.ResourceWithImportState = &domainResource{}
+var _ resource.ResourceWithValidateConfig = &domainResource{}
+var _ resource.ResourceWithModifyPlan = &domainResource{}
+
+// maxRegistrationYears ist die längste Laufzeit, die Registries erlauben.
+const maxRegistrationYears = 10
+
+// listingGracePeriod ist die Zeit nach einer Registrierung, in der eine fehlende
+// Domain in der Domain-Liste nicht als gelöscht gilt.
+const listingGracePeriod = 24 * time.Hour
+
+func NewDomainResource() resource.Resource {
+	return &domainResource{}
+}
+
+type domainResource struct {
+	client *porkbun.Client
+}
+
+type domainResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Domain        types.String `tfsdk:"domain"`
+	Years         types.Int64  `tfsdk:"years"`
+	ExpectedPrice types.String `tfsdk:"expected_price"`
+	Status        types.String `tfsdk:"status"`
+	CreateDate    types.String `tfsdk:"create_date"`
+	ExpireDate    types.String `tfsdk:"expire_date"`
+	AutoRenew     types.Bool   `tfsdk:"auto_renew"`
+}
+
+func (r *domainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Registriert eine Domain bei Porkbun und belastet dafür das Guthaben des Kontos. " +
+			"Domains können nicht gelöscht werden: Beim Zerstören wird die Domain nur aus dem State entfernt und bleibt registriert. " +
+			"Ist die Domain bereits im Konto, wird sie ohne Kosten übernommen.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Wird auf den Domainnamen gesetzt.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der zu registrierende Domainname (z.B. 'example.com').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"years": schema.Int64Attribute{
+				Description: "Die Laufzeit der Registrierung in Jahren. Standard ist 1. Wird nur bei der Registrierung verwendet.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+			},
+			"expected_price": schema.StringAttribute{
+				Description: "Der erwartete Gesamtpreis der Registrierung in USD (z.B. '11.08'), als ausdrückliche Bestätigung der Kosten. " +
+					"Weicht der aktuelle Preis von Porkbun ab, schlägt bereits der Plan fehl.",
+				Required: true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Der aktuelle Status der Domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				Description: "Das Registrierungsdatum im RFC3339-Format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expire_date": schema.StringAttribute{
+				Description: "Das Ablaufdatum im RFC3339-Format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_renew": schema.BoolAttribute{
+				Description: "Ob die automatische Verlängerung aktiv ist.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *domainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *provider.resourceData, erhalten: %T", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *domainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config domainResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if y := config.Years; !y.IsNull() && !y.IsUnknown() && (y.ValueInt64() < 1 || y.ValueInt64() > maxRegistrationYears) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("years"),
+			"Ungültige Laufzeit",
+			fmt.Sprintf("Die Laufzeit muss zwischen 1 und %d Jahren liegen, erhalten: %d.", maxRegistrationYears, y.ValueInt64()),
+		)
+	}
+	if p := config.ExpectedPrice; !p.IsNull() && !p.IsUnknown() {
+		if _, err := priceToCents(p.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expected_price"), "Ungültiger Preis", err.Error())
+		}
+	}
+}
+
+// ModifyPlan prüft vor einer Registrierung, ob expected_price zum aktuellen
+// Preis passt. Domains, die bereits im Konto sind, werden kostenlos übernommen.
+// Der Preis kommt aus domain/checkDomain und gilt damit für genau diese Domain,
+// einschließlich Premium- und Aktionspreisen: das erste Jahr kostet den
+// Registrierungspreis, jedes weitere den Verlängerungspreis.
+func (r *domainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Domain.IsUnknown() || plan.Years.IsUnknown() || plan.ExpectedPrice.IsUnknown() {
+		return
+	}
+	domainName := strings.ToLower(plan.Domain.ValueString())
+
+	listing, err := r.findDomain(ctx, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
+		return
+	}
+	if listing != nil {
+		resp.Diagnostics.AddWarning(
+			"Domain wird übernommen",
+			fmt.Sprintf("%s ist bereits im Porkbun-Konto und wird ohne erneute Registrierung übernommen.", domainName),
+		)
+		return
+	}
+
+	check, err := r.client.CheckDomain(ctx, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler bei der Verfügbarkeitsprüfung", fmt.Sprintf("Konnte Preis und Verfügbarkeit von %s nicht prüfen: %s", domainName, err.Error()))
+		return
+	}
+	availability := check.Response
+	if !bool(availability.Avail) {
+		resp.Diagnostics.AddAttributeError(path.Root("domain"), "Domain nicht verfügbar", fmt.Sprintf("%s kann nicht registriert werden.", domainName))
+		return
+	}
+
+	firstYear, err := priceToCents(availability.Price)
+	if err != nil {
+		resp.Diagnostics.AddError("Ungültiger Preis von Porkbun", fmt.Sprintf("Registrierungspreis für %s: %s", domainName, err.Error()))
+		return
+	}
+	renewalPrice := availability.Additional.Renewal.Price
+	if renewalPrice == "" {
+		renewalPrice = availability.Price
+	}
+	renewal, err := priceToCents(renewalPrice)
+	if err != nil {
+		resp.Diagnostics.AddError("Ungültiger Preis von Porkbun", fmt.Sprintf("Verlängerungspreis für %s: %s", domainName, err.Error()))
+		return
+	}
+	expected, err := priceToCents(plan.ExpectedPrice.ValueString())
+	if err != nil {
+		return // Bereits in ValidateConfig gemeldet.
+	}
+	if total := firstYear + renewal*(plan.Years.ValueInt64()-1); total != expected {
+		kind := ""
+		if bool(availability.Premium) {
+			kind = " Es handelt sich um eine Premium-Domain."
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expected_price"),
+			"Unerwarteter Preis",
+			fmt.Sprintf("Die Registrierung von %s für %d Jahr(e) kostet %s USD (%s USD für das erste Jahr, %s USD für jedes weitere), erwartet wurden %s USD.%s "+
+				"Passe expected_price an, um die Kosten zu bestätigen.",
+				domainName, plan.Years.ValueInt64(), centsToPrice(total), availability.Price, renewalPrice, plan.ExpectedPrice.ValueString(), kind),
+		)
+	}
+}
+
+func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domainName := strings.ToLower(plan.Domain.ValueString())
+
+	listing, err := r.findDomain(ctx, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
+		return
+	}
+
+	if listing != nil {
+		tflog.Warn(ctx, "Domain ist bereits im Konto und wird übernommen.", map[string]any{"domain": domainName})
+	} else {
+		cost, err := priceToCents(plan.ExpectedPrice.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expected_price"), "Ungültiger Preis", err.Error())
+			return
+		}
+
+		order, err := r.client.CreateDomain(ctx, domainName, cost, plan.Years.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Fehler beim Registrieren der Domain", fmt.Sprintf("Konnte %s nicht registrieren: %s", domainName, err.Error()))
+			return
+		}
+		tflog.Info(ctx, "Domain registriert", map[string]any{"domain": domainName, "order_id": order.OrderID.String(), "cost": order.Cost.String()})
+
+		// Die Domain ist bezahlt, taucht aber unter Umständen erst mit Verzögerung
+		// in der Domain-Liste auf. Ohne Listing wird kein halber State gespeichert:
+		// beim nächsten Apply findet Create die Domain und übernimmt sie.
+		listing, err = r.client.WaitForDomain(ctx, domainName)
+		if errors.Is(err, porkbun.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Domain registriert, aber noch nicht gelistet",
+				fmt.Sprintf("%s wurde registriert (Bestellung %s), erscheint aber noch nicht in der Domain-Liste. "+
+					"Führe terraform apply später erneut aus, die Domain wird dann ohne erneute Registrierung übernommen. "+
+					"Alternativ kann sie mit terraform import importiert werden.", domainName, order.OrderID.String()),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(plan.Domain.ValueString())
+	setDomainListing(&plan, listing)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listing, err := r.findDomain(ctx, strings.ToLower(state.Domain.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
+		return
+	}
+	if listing == nil && recentlyRegistered(state) {
+		// Frisch registrierte Domains fehlen mitunter noch in der Liste.
+		tflog.Warn(ctx, "Kürzlich registrierte Domain fehlt in der Domain-Liste, State bleibt unverändert.", map[string]any{"domain": state.Domain.ValueString()})
+		return
+	}
+	if listing == nil {
+		tflog.Warn(ctx, "Domain nicht mehr im Konto, wird aus dem State entfernt.", map[string]any{"domain": state.Domain.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setDomainListing(&state, listing)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update übernimmt nur years und expected_price in den State. Beide wirken
+// sich erst bei einer Registrierung aus.
+func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Status = state.Status
+	plan.CreateDate = state.CreateDate
+	plan.ExpireDate = state.ExpireDate
+	plan.AutoRenew = state.AutoRenew
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete entfernt die Domain nur aus dem State, da Porkbun Domains nicht löschen kann.
+func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Domain bleibt registriert",
+		fmt.Sprintf("%s wurde nur aus dem Terraform-State entfernt. Die Domain bleibt im Porkbun-Konto registriert, bis sie abläuft.", state.Domain.ValueString()),
+	)
+}
+
+func (r *domainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("years"), int64(1))...)
+}
+
+func (r *domainResource) findDomain(ctx context.Context, domainName string) (*porkbun.DomainListing, error) {
+	domains, err := r.client.ListAllDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range domains {
+		if strings.EqualFold(domains[i].Domain, domainName) {
+			return &domains[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// setDomainListing überträgt die berechneten Attribute aus listing.
+func setDomainListing(model *domainResourceModel, listing *porkbun.DomainListing) {
+	model.Status = types.StringValue(listing.Status)
+	model.AutoRenew = types.BoolValue(bool(listing.AutoRenew))
+	model.CreateDate, model.ExpireDate = types.StringNull(), types.StringNull()
+	if !listing.CreateDate.IsZero() {
+		model.CreateDate = types.StringValue(listing.CreateDate.Format(time.RFC3339))
+	}
+	if !listing.ExpireDate.IsZero() {
+		model.ExpireDate = types.StringValue(listing.ExpireDate.Format(time.RFC3339))
+	}
+}
+
+// recentlyRegistered meldet, ob die Domain laut State vor weniger als
+// listingGracePeriod registriert wurde. Solche Domains fehlen mitunter noch in
+// der Domain-Liste.
+func recentlyRegistered(state domainResourceModel) bool {
+	created, err := time.Parse(time.RFC3339, state.CreateDate.ValueString())
+	return err == nil && time.Since(created) < listingGracePeriod
+}
+
+func priceToCents(price string) (int64, error) {
+	dollars, err := strconv.ParseFloat(strings.TrimSpace(price), 64)
+	if err != nil || dollars < 0 || math.IsInf(dollars, 0) {
+		return 0, fmt.Errorf("%q ist kein gültiger Preis in USD", price)
+	}
+	return int64(math.Round(dollars * 100)), nil
+}
+
+func centsToPrice(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
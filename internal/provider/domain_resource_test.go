@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDomainResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	const newDomain = "registered.com"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := server.Domain(newDomain); !ok {
+				return fmt.Errorf("destroy must not remove %s from the account", newDomain)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// .com costs 11.08 per year in the fake.
+				Config:      testAccDomainResourceConfig(newDomain, 2, "11.08"),
+				ExpectError: regexp.MustCompile(`(?s)Unerwarteter Preis.*22\.16 USD`),
+			},
+			{
+				PreConfig: server.ResetRequests,
+				Config:    testAccDomainResourceConfig(newDomain, 2, "22.16"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_domain.test", "id", newDomain),
+					resource.TestCheckResourceAttr("porkbun_domain.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("porkbun_domain.test", "auto_renew", "true"),
+					resource.TestCheckResourceAttrSet("porkbun_domain.test", "expire_date"),
+					func(*terraform.State) error {
+						d, ok := server.Domain(newDomain)
+						if !ok {
+							return fmt.Errorf("%s was not registered", newDomain)
+						}
+						if !regexp.MustCompile(fmt.Sprintf(`^%d-`, time.Now().Year()+2)).MatchString(d.ExpireDate) {
+							return fmt.Errorf("expected a two year registration, expires %s", d.ExpireDate)
+						}
+						if n := server.RequestCount("domain/create"); n != 1 {
+							return fmt.Errorf("expected 1 domain/create request, got %d", n)
+						}
+						return nil
+					},
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:            "porkbun_domain.test",
+				ImportState:             true,
+				ImportStateId:           newDomain,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"years", "expected_price"},
+			},
+			{
+				// Price changes after registration are irrelevant.
+				PreConfig: server.ResetRequests,
+				Config:    testAccDomainResourceConfig(newDomain, 3, "1.00"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_domain.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if n := server.RequestCount("domain/create"); n != 0 {
+						return fmt.Errorf("expected no domain/create request, got %d", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_adopt(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainResourceConfig(testAccDomain, 1, "0.00"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_domain.test", "id", testAccDomain),
+					resource.TestCheckResourceAttr("porkbun_domain.test", "create_date", "2020-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("porkbun_domain.test", "expire_date", "2030-01-01T00:00:00Z"),
+					func(*terraform.State) error {
+						if n := server.RequestCount("domain/create"); n != 0 {
+							return fmt.Errorf("expected an existing domain to be adopted, got %d domain/create requests", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_delayedListing(t *testing.T) {
+	server, factories := testAccSetup(t)
+	const newDomain = "delayed.com"
+	server.DelayListing(newDomain, 2)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainResourceConfig(newDomain, 1, "11.08"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_domain.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrSet("porkbun_domain.test", "create_date"),
+					resource.TestCheckResourceAttrSet("porkbun_domain.test", "expire_date"),
+				),
+			},
+			{
+				// A listing that lags behind again must not drop the domain.
+				PreConfig:    func() { server.DelayListing(newDomain, 1) },
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("porkbun_domain.test", "status", "ACTIVE"),
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_listingNeverShowsUp(t *testing.T) {
+	server, factories := testAccSetup(t)
+	const newDomain = "slow.com"
+	server.DelayListing(newDomain, 1000)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainResourceConfig(newDomain, 1, "11.08"),
+				ExpectError: regexp.MustCompile(`Domain registriert, aber noch nicht gelistet`),
+			},
+			{
+				// Once listed, the next apply adopts the domain instead of
+				// registering it a second time.
+				PreConfig: func() {
+					server.DelayListing(newDomain, 0)
+					server.ResetRequests()
+				},
+				Config: testAccDomainResourceConfig(newDomain, 1, "11.08"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_domain.test", "status", "ACTIVE"),
+					func(*terraform.State) error {
+						if n := server.RequestCount("domain/create"); n != 0 {
+							return fmt.Errorf("expected no second domain/create request, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_pricing(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.SetPricing(map[string]porkbun.TldPricing{
+		"com": {Registration: "5.00", Renewal: "11.08", Transfer: "11.08"},
+	})
+	server.SetAvailability("fancy.com", true, "2500.00")
+	server.SetAvailability("taken.com", false, "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainResourceConfig("taken.com", 1, "5.00"),
+				ExpectError: regexp.MustCompile(`Domain nicht verfügbar`),
+			},
+			{
+				// Premium domains are not priced like their TLD.
+				Config:      testAccDomainResourceConfig("fancy.com", 1, "5.00"),
+				ExpectError: regexp.MustCompile(`(?s)Unerwarteter Preis.*2500\.00 USD.*Premium-Domain`),
+			},
+			{
+				// A promotional first year, then the renewal price.
+				Config:      testAccDomainResourceConfig("promo.com", 2, "10.00"),
+				ExpectError: regexp.MustCompile(`(?s)Unerwarteter Preis.*16\.08 USD`),
+			},
+			{
+				Config: testAccDomainResourceConfig("promo.com", 2, "16.08"),
+				Check:  resource.TestCheckResourceAttr("porkbun_domain.test", "status", "ACTIVE"),
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_unsupportedTld(t *testing.T) {
+	_, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainResourceConfig("example.invalid", 1, "10.00"),
+				ExpectError: regexp.MustCompile(`(?s)Verfügbarkeitsprüfung.*unsupported TLD`),
+			},
+			{
+				Config:      testAccDomainResourceConfig("example.com", 11, "10.00"),
+				ExpectError: regexp.MustCompile(`Ungültige Laufzeit`),
+			},
+		},
+	})
+}
+
+func testAccDomainResourceConfig(domain string, years int, price string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_domain" "test" {
+  domain         = %q
+  years          = %d
+  expected_price = %q
+}
+`, domain, years, price)
+}
@@ -3,13 +3,19 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,8 +31,65 @@ type domainsDataSource struct {
 }
 
 type domainsDataSourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Domains types.List   `tfsdk:"domains"`
+	ID                 types.String `tfsdk:"id"`
+	Tlds               types.List   `tfsdk:"tlds"`
+	Status             types.String `tfsdk:"status"`
+	Label              types.String `tfsdk:"label"`
+	AutoRenew          types.Bool   `tfsdk:"auto_renew"`
+	NameRegex          types.String `tfsdk:"name_regex"`
+	ExpiringWithinDays types.Int64  `tfsdk:"expiring_within_days"`
+	Domains            types.List   `tfsdk:"domains"`
+	DomainNames        types.List   `tfsdk:"domain_names"`
+}
+
+// domainsFilter enthält die ausgewerteten Filterargumente. Leere Felder
+// filtern nicht.
+type domainsFilter struct {
+	tlds               []string
+	status             string
+	label              string
+	autoRenew          *bool
+	nameRegex          *regexp.Regexp
+	expiringWithinDays *int64
+	now                time.Time
+}
+
+func (f domainsFilter) matches(domain porkbun.DomainListing) bool {
+	if len(f.tlds) > 0 && !slices.Contains(f.tlds, strings.ToLower(domain.Tld)) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(f.status, domain.Status) {
+		return false
+	}
+	if f.label != "" && !slices.ContainsFunc(domain.Labels, func(l porkbun.DomainLabel) bool { return l.Title == f.label }) {
+		return false
+	}
+	if f.autoRenew != nil && bool(domain.AutoRenew) != *f.autoRenew {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(domain.Domain) {
+		return false
+	}
+	if f.expiringWithinDays != nil {
+		if domain.ExpireDate.IsZero() || domain.ExpireDate.After(f.now.AddDate(0, 0, int(*f.expiringWithinDays))) {
+			return false
+		}
+	}
+	return true
+}
+
+// domainsFilterID leitet aus den Filterargumenten eine stabile ID ab, damit
+// gleiche Abfragen keine Diffs erzeugen.
+func domainsFilterID(config domainsDataSourceModel, tlds []string) string {
+	key := strings.Join([]string{
+		strings.Join(tlds, ","),
+		config.Status.String(),
+		config.Label.String(),
+		config.AutoRenew.String(),
+		config.NameRegex.String(),
+		config.ExpiringWithinDays.String(),
+	}, "\n")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))[:16]
 }
 
 func domainListingAttributeTypes() map[string]attr.Type {
@@ -39,22 +102,7 @@ func domainListingAttributeTypes() map[string]attr.Type {
 		"security_lock": types.BoolType,
 		"whois_privacy": types.BoolType,
 		"auto_renew":    types.BoolType,
-	}
-}
-
-func toBool(v interface{}) bool {
-	if v == nil {
-		return false
-	}
-	switch val := v.(type) {
-	case bool:
-		return val
-	case string:
-		return val == "true"
-	case float64:
-		return val == 1
-	default:
-		return false
+		"labels":        types.ListType{ElemType: types.StringType},
 	}
 }
 
@@ -67,11 +115,36 @@ func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 		Description: "Ruft eine Liste aller Domains ab, die sich im Porkbun-Konto befinden.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Ein eindeutiger Bezeichner, der auf den aktuellen Zeitstempel gesetzt wird.",
+				Description: "Ein aus den Filterargumenten abgeleiteter, stabiler Bezeichner.",
 				Computed:    true,
 			},
+			"tlds": schema.ListAttribute{
+				Description: "Nur Domains mit einer dieser TLDs zurückgeben (z. B. `[\"com\", \"net\"]`).",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Nur Domains mit diesem Status zurückgeben (z. B. `ACTIVE`). Groß-/Kleinschreibung wird ignoriert.",
+				Optional:    true,
+			},
+			"label": schema.StringAttribute{
+				Description: "Nur Domains zurückgeben, denen das Konto-Label mit diesem Titel zugewiesen ist.",
+				Optional:    true,
+			},
+			"auto_renew": schema.BoolAttribute{
+				Description: "Nur Domains zurückgeben, deren automatische Verlängerung diesem Wert entspricht.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Nur Domains zurückgeben, deren Name auf diesen regulären Ausdruck (RE2-Syntax) passt.",
+				Optional:    true,
+			},
+			"expiring_within_days": schema.Int64Attribute{
+				Description: "Nur Domains zurückgeben, die innerhalb dieser Anzahl von Tagen ablaufen (bereits abgelaufene eingeschlossen).",
+				Optional:    true,
+			},
 			"domains": schema.ListNestedAttribute{
-				Description: "Die Liste der Domains im Konto.",
+				Description: "Die Liste der Domains im Konto, die allen Filtern entsprechen.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -83,9 +156,19 @@ func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						"security_lock": schema.BoolAttribute{Computed: true},
 						"whois_privacy": schema.BoolAttribute{Computed: true},
 						"auto_renew":    schema.BoolAttribute{Computed: true},
+						"labels": schema.ListAttribute{
+							Description: "Die Titel der Konto-Labels, die der Domain zugewiesen sind.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
 					},
 				},
 			},
+			"domain_names": schema.ListAttribute{
+				Description: "Die Namen der gefundenen Domains, in derselben Reihenfolge wie `domains`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -103,14 +186,69 @@ func (d *domainsDataSource) Configure(ctx context.Context, req datasource.Config
 }
 
 func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	domainListings, err := d.client.ListAllDomains()
+	var config domainsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := domainsFilter{
+		status: config.Status.ValueString(),
+		label:  config.Label.ValueString(),
+		now:    time.Now().UTC(),
+	}
+	if !config.Tlds.IsNull() {
+		var tlds []string
+		resp.Diagnostics.Append(config.Tlds.ElementsAs(ctx, &tlds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, tld := range tlds {
+			filter.tlds = append(filter.tlds, strings.ToLower(strings.TrimPrefix(tld, ".")))
+		}
+	}
+	if !config.AutoRenew.IsNull() {
+		autoRenew := config.AutoRenew.ValueBool()
+		filter.autoRenew = &autoRenew
+	}
+	if !config.NameRegex.IsNull() {
+		re, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Ungültiger regulärer Ausdruck", err.Error())
+			return
+		}
+		filter.nameRegex = re
+	}
+	if !config.ExpiringWithinDays.IsNull() {
+		days := config.ExpiringWithinDays.ValueInt64()
+		if days < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expiring_within_days"),
+				"Ungültiger Filter",
+				"expiring_within_days darf nicht negativ sein, erhalten: "+strconv.FormatInt(days, 10),
+			)
+			return
+		}
+		filter.expiringWithinDays = &days
+	}
+
+	domainListings, err := d.client.ListAllDomains(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Abrufen der Domain-Liste", err.Error())
 		return
 	}
 
-	var domainModels []attr.Value
+	domainModels := []attr.Value{}
+	domainNames := []attr.Value{}
 	for _, domain := range domainListings {
+		if !filter.matches(domain) {
+			continue
+		}
+
+		labels := make([]attr.Value, 0, len(domain.Labels))
+		for _, label := range domain.Labels {
+			labels = append(labels, types.StringValue(label.Title))
+		}
 
 		domainModels = append(domainModels, types.ObjectValueMust(
 			domainListingAttributeTypes(),
@@ -118,18 +256,21 @@ func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 				"domain":        types.StringValue(domain.Domain),
 				"status":        types.StringValue(domain.Status),
 				"tld":           types.StringValue(domain.Tld),
-				"create_date":   types.StringValue(domain.CreateDate),
-				"expire_date":   types.StringValue(domain.ExpireDate),
-				"security_lock": types.BoolValue(toBool(domain.SecurityLock)),
-				"whois_privacy": types.BoolValue(toBool(domain.WhoisPrivacy)),
-				"auto_renew":    types.BoolValue(toBool(domain.AutoRenew)),
+				"create_date":   types.StringValue(domain.CreateDate.String()),
+				"expire_date":   types.StringValue(domain.ExpireDate.String()),
+				"security_lock": types.BoolValue(bool(domain.SecurityLock)),
+				"whois_privacy": types.BoolValue(bool(domain.WhoisPrivacy)),
+				"auto_renew":    types.BoolValue(bool(domain.AutoRenew)),
+				"labels":        types.ListValueMust(types.StringType, labels),
 			},
 		))
+		domainNames = append(domainNames, types.StringValue(domain.Domain))
 	}
 
-	var state domainsDataSourceModel
-	state.ID = types.StringValue(time.Now().UTC().String())
+	state := config
+	state.ID = types.StringValue(domainsFilterID(config, filter.tlds))
 	state.Domains = types.ListValueMust(types.ObjectType{AttrTypes: domainListingAttributeTypes()}, domainModels)
+	state.DomainNames = types.ListValueMust(types.StringType, domainNames)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainsDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.AddDomain(porkbuntest.Domain{
+		Name:   "example.net",
+		Status: "EXPIRED",
+		Labels: []porkbuntest.Label{{ID: "27240", Title: "customer-a", Color: "#ff0000"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `data "porkbun_domains" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.#", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.0.domain", "example.com"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.0.tld", "com"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.0.status", "ACTIVE"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.1.domain", "example.net"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.1.status", "EXPIRED"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.1.expire_date", "2030-01-01 00:00:00"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.0.labels.#", "0"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.1.labels.#", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.test", "domains.1.labels.0", "customer-a"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainsDataSource_filters(t *testing.T) {
+	server, factories := testAccSetup(t)
+	soon := time.Now().UTC().AddDate(0, 0, 10).Format("2006-01-02 15:04:05")
+	server.AddDomain(porkbuntest.Domain{Name: "shop.net", AutoRenew: true, ExpireDate: soon})
+	server.AddDomain(porkbuntest.Domain{
+		Name:   "shop.org",
+		Labels: []porkbuntest.Label{{ID: "1", Title: "customer-a"}},
+	})
+	server.AddDomain(porkbuntest.Domain{Name: "old.org", Status: "EXPIRED"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+data "porkbun_domains" "tld" {
+  tlds = ["org", ".NET"]
+}
+
+data "porkbun_domains" "tld_again" {
+  tlds = ["org", ".NET"]
+}
+
+data "porkbun_domains" "label" {
+  label = "customer-a"
+}
+
+data "porkbun_domains" "combined" {
+  name_regex = "^shop\\."
+  auto_renew = true
+}
+
+data "porkbun_domains" "expiring" {
+  expiring_within_days = 30
+}
+
+data "porkbun_domains" "status" {
+  status = "expired"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_domains.tld", "domain_names.#", "3"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.tld", "domain_names.0", "old.org"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.tld", "domain_names.1", "shop.net"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.tld", "domain_names.2", "shop.org"),
+					resource.TestCheckResourceAttrPair("data.porkbun_domains.tld", "id", "data.porkbun_domains.tld_again", "id"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.label", "domain_names.#", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.label", "domain_names.0", "shop.org"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.combined", "domain_names.#", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.combined", "domain_names.0", "shop.net"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.expiring", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.expiring", "domains.0.domain", "shop.net"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.status", "domain_names.#", "1"),
+					resource.TestCheckResourceAttr("data.porkbun_domains.status", "domain_names.0", "old.org"),
+				),
+			},
+			{
+				Config:      testAccProviderConfig() + `data "porkbun_domains" "bad" { name_regex = "(" }`,
+				ExpectError: regexp.MustCompile(`Ungültiger regulärer Ausdruck`),
+			},
+		},
+	})
+}
@@ -3,8 +3,8 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
@@ -78,12 +78,12 @@ func (r *glueRecordResource) Configure(ctx context.Context, req resource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*porkbun.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
-		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *porkbun.Client, erhalten: %T", req.ProviderData))
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *provider.resourceData, erhalten: %T", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func (r *glueRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -102,7 +102,7 @@ func (r *glueRecordResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	err := r.client.AddGlueRecord(domain, host, ipList)
+	err := r.client.AddGlueRecord(ctx, domain, host, ipList)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Erstellen des Glue Records", "Konnte Glue Record nicht hinzufügen: "+err.Error())
 		return
@@ -122,7 +122,7 @@ func (r *glueRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	domain := state.Domain.ValueString()
 	host := state.Host.ValueString()
 
-	allGlueRecords, err := r.client.GetGlueRecords(domain)
+	allGlueRecords, err := r.client.GetGlueRecords(ctx, domain)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Lesen der Glue Records", "Konnte Glue Records für die Domain nicht abrufen: "+err.Error())
 		return
@@ -135,9 +135,13 @@ func (r *glueRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	sort.Strings(foundIPs)
+	var currentIPs []string
+	resp.Diagnostics.Append(state.IPs.ElementsAs(ctx, &currentIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	state.IPs, resp.Diagnostics = types.ListValueFrom(ctx, types.StringType, foundIPs)
+	state.IPs, resp.Diagnostics = types.ListValueFrom(ctx, types.StringType, orderLike(foundIPs, currentIPs))
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -162,13 +166,13 @@ func (r *glueRecordResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	err := r.client.DeleteGlueRecord(domain, host)
-	if err != nil && !strings.Contains(err.Error(), "Could not find glue record") {
+	err := r.client.DeleteGlueRecord(ctx, domain, host)
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
 		resp.Diagnostics.AddError("Fehler beim Aktualisieren (Löschen) des Glue Records", err.Error())
 		return
 	}
 
-	err = r.client.AddGlueRecord(domain, host, newIPs)
+	err = r.client.AddGlueRecord(ctx, domain, host, newIPs)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Aktualisieren (Hinzufügen) des Glue Records", err.Error())
 		return
@@ -185,8 +189,8 @@ func (r *glueRecordResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeleteGlueRecord(state.Domain.ValueString(), state.Host.ValueString())
-	if err != nil && !strings.Contains(err.Error(), "Could not find glue record") {
+	err := r.client.DeleteGlueRecord(ctx, state.Domain.ValueString(), state.Host.ValueString())
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
 		resp.Diagnostics.AddError("Fehler beim Löschen des Glue Records", err.Error())
 		return
 	}
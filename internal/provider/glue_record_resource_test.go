@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccGlueRecordResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy: func(*terraform.State) error {
+			d, _ := server.Domain(testAccDomain)
+			if len(d.Glue) != 0 {
+				return fmt.Errorf("expected glue records to be destroyed, found %v", d.Glue)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// IPv6 first to catch ordering drift, the API returns v4 first.
+				Config: testAccGlueRecordConfig("2001:db8::1", "192.0.2.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_glue_record.test", "id", testAccDomain+"/ns1"),
+					resource.TestCheckResourceAttr("porkbun_glue_record.test", "ips.#", "2"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:            "porkbun_glue_record.test",
+				ImportState:             true,
+				ImportStateId:           testAccDomain + "/ns1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ips"},
+			},
+			{
+				Config: testAccGlueRecordConfig("192.0.2.2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_glue_record.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: func(*terraform.State) error {
+					d, _ := server.Domain(testAccDomain)
+					if ips := d.Glue["ns1"]; len(ips) != 1 || ips[0] != "192.0.2.2" {
+						return fmt.Errorf("unexpected glue IPs %v", ips)
+					}
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						delete(d.Glue, "ns1")
+					})
+				},
+				Config: testAccGlueRecordConfig("192.0.2.2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_glue_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccGlueRecordConfig(ips ...string) string {
+	list := ""
+	for _, ip := range ips {
+		list += fmt.Sprintf("%q, ", ip)
+	}
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_glue_record" "test" {
+  domain = %q
+  host   = "ns1"
+  ips    = [%s]
+}
+`, testAccDomain, list)
+}
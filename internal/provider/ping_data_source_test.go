@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPingDataSource(t *testing.T) {
+	_, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `data "porkbun_ping" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_ping.test", "your_ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.porkbun_ping.test", "id", "127.0.0.1"),
+				),
+			},
+		},
+	})
+}
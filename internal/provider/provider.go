@@ -2,25 +2,58 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ provider.Provider = &PorkbunProvider{}
+var _ provider.ProviderWithEphemeralResources = &PorkbunProvider{}
 
 type PorkbunProvider struct {
 	version string
 }
 
 type PorkbunProviderModel struct {
-	APIKey    types.String `tfsdk:"api_key"`
-	SecretKey types.String `tfsdk:"secret_api_key"`
+	APIKey       types.String  `tfsdk:"api_key"`
+	SecretKey    types.String  `tfsdk:"secret_api_key"`
+	MaxRetries   types.Int64   `tfsdk:"max_retries"`
+	MaxRetryWait types.String  `tfsdk:"max_retry_wait"`
+	BaseURL      types.String  `tfsdk:"base_url"`
+	Timeout      types.String  `tfsdk:"request_timeout"`
+	HTTPProxy    types.String  `tfsdk:"http_proxy"`
+	CABundleFile types.String  `tfsdk:"ca_bundle_file"`
+	Insecure     types.Bool    `tfsdk:"insecure_skip_verify"`
+	RateLimit    types.Float64 `tfsdk:"requests_per_second"`
+	MaxInFlight  types.Int64   `tfsdk:"max_concurrent_requests"`
+	CacheTTL     types.String  `tfsdk:"cache_ttl"`
+	AdoptRecords types.Bool    `tfsdk:"adopt_existing_records"`
+	OwnerMarker  types.String  `tfsdk:"record_ownership_marker"`
+}
+
+// resourceData is handed to resources on configuration. Data sources and
+// ephemeral resources only get the client.
+type resourceData struct {
+	client *porkbun.Client
+	// adoptExistingRecords is the default for adopt_existing of
+	// porkbun_dns_record.
+	adoptExistingRecords bool
+	// ownershipMarker is added to the notes of all DNS records written by the
+	// provider, unless it is empty.
+	ownershipMarker string
 }
 
 func (p *PorkbunProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -42,6 +75,54 @@ func (p *PorkbunProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for rate-limited or transiently failing API calls. Defaults to 3, `0` disables retries. May be provided via PORKBUN_MAX_RETRIES environment variable.",
+				Optional:            true,
+			},
+			"max_retry_wait": schema.StringAttribute{
+				MarkdownDescription: "Upper bound for the wait between two retries as a Go duration (e.g. `30s`). Defaults to `30s`. May be provided via PORKBUN_MAX_RETRY_WAIT environment variable.",
+				Optional:            true,
+			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Porkbun JSON API. Defaults to `https://api.porkbun.com/api/json/v3`. May be provided via PORKBUN_BASE_URL environment variable.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for a single HTTP request as a Go duration (e.g. `60s`). Defaults to `60s`. May be provided via PORKBUN_REQUEST_TIMEOUT environment variable.",
+				Optional:            true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP(S) proxy for all API requests. When unset, the standard HTTPS_PROXY/NO_PROXY environment variables apply. May be provided via PORKBUN_HTTP_PROXY environment variable.",
+				Optional:            true,
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with additional CA certificates to trust, e.g. for a TLS-intercepting proxy. May be provided via PORKBUN_CA_BUNDLE_FILE environment variable.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable TLS certificate verification. Only meant for lab setups. May be provided via PORKBUN_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Average number of API requests per second shared by all resources. Unlimited by default. May be provided via PORKBUN_REQUESTS_PER_SECOND environment variable.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time. Unlimited by default. May be provided via PORKBUN_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional:            true,
+			},
+			"cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long zone listings read from the API are reused as a Go duration (e.g. `5m`). By default they are kept for the whole run. May be provided via PORKBUN_CACHE_TTL environment variable.",
+				Optional:            true,
+			},
+			"adopt_existing_records": schema.BoolAttribute{
+				MarkdownDescription: "Default for `adopt_existing` of `porkbun_dns_record`: take over an identical existing record instead of failing to create it. Records carrying the `record_ownership_marker` are taken over even if this is `false`. Defaults to `false`. May be provided via PORKBUN_ADOPT_EXISTING_RECORDS environment variable.",
+				Optional:            true,
+			},
+			"record_ownership_marker": schema.StringAttribute{
+				MarkdownDescription: "Text added to the notes of every DNS record the provider creates or updates (e.g. `managed-by-terraform`), to tell them apart from records managed by hand. It is not part of the `notes` attribute. May be provided via PORKBUN_RECORD_OWNERSHIP_MARKER environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -75,8 +156,203 @@ func (p *PorkbunProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	client := porkbun.NewClient(apiKey, secretKey)
-	resp.ResourceData = client
+
+	baseURL := os.Getenv("PORKBUN_BASE_URL")
+	if !data.BaseURL.IsNull() {
+		baseURL = data.BaseURL.ValueString()
+	}
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("base_url"),
+				"Invalid Endpoint Configuration",
+				fmt.Sprintf("base_url must be an absolute http or https URL, got: %q", baseURL),
+			)
+			return
+		}
+		client.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	var transport porkbun.TransportOptions
+
+	timeout := os.Getenv("PORKBUN_REQUEST_TIMEOUT")
+	if !data.Timeout.IsNull() {
+		timeout = data.Timeout.ValueString()
+	}
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Timeout Configuration",
+				fmt.Sprintf("request_timeout must be a positive duration such as \"60s\", got: %q", timeout),
+			)
+			return
+		}
+		transport.Timeout = d
+	}
+
+	transport.ProxyURL = os.Getenv("PORKBUN_HTTP_PROXY")
+	if !data.HTTPProxy.IsNull() {
+		transport.ProxyURL = data.HTTPProxy.ValueString()
+	}
+
+	caBundleFile := os.Getenv("PORKBUN_CA_BUNDLE_FILE")
+	if !data.CABundleFile.IsNull() {
+		caBundleFile = data.CABundleFile.ValueString()
+	}
+	if caBundleFile != "" {
+		pem, err := os.ReadFile(caBundleFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_bundle_file"),
+				"Invalid TLS Configuration",
+				fmt.Sprintf("Could not read CA bundle: %s", err),
+			)
+			return
+		}
+		transport.CABundlePEM = pem
+	}
+
+	if v := os.Getenv("PORKBUN_INSECURE_SKIP_VERIFY"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid TLS Configuration",
+				fmt.Sprintf("PORKBUN_INSECURE_SKIP_VERIFY must be a boolean, got: %q", v),
+			)
+			return
+		}
+		transport.InsecureSkipVerify = insecure
+	}
+	if !data.Insecure.IsNull() {
+		transport.InsecureSkipVerify = data.Insecure.ValueBool()
+	}
+	if transport.InsecureSkipVerify {
+		tflog.Warn(ctx, "TLS certificate verification for the Porkbun API is disabled")
+	}
+
+	httpClient, err := porkbun.NewHTTPClient(transport)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Configuration", err.Error())
+		return
+	}
+	client.HTTPClient = httpClient
+
+	requestsPerSecond := os.Getenv("PORKBUN_REQUESTS_PER_SECOND")
+	if !data.RateLimit.IsNull() {
+		requestsPerSecond = strconv.FormatFloat(data.RateLimit.ValueFloat64(), 'f', -1, 64)
+	}
+	if requestsPerSecond != "" {
+		rps, err := strconv.ParseFloat(requestsPerSecond, 64)
+		if err != nil || rps <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Rate Limit Configuration",
+				fmt.Sprintf("requests_per_second must be a positive number, got: %q", requestsPerSecond),
+			)
+			return
+		}
+		client.SetRateLimit(rps)
+	}
+
+	maxInFlight := os.Getenv("PORKBUN_MAX_CONCURRENT_REQUESTS")
+	if !data.MaxInFlight.IsNull() {
+		maxInFlight = strconv.FormatInt(data.MaxInFlight.ValueInt64(), 10)
+	}
+	if maxInFlight != "" {
+		n, err := strconv.Atoi(maxInFlight)
+		if err != nil || n <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Rate Limit Configuration",
+				fmt.Sprintf("max_concurrent_requests must be a positive integer, got: %q", maxInFlight),
+			)
+			return
+		}
+		client.SetMaxConcurrentRequests(n)
+	}
+
+	maxRetries := os.Getenv("PORKBUN_MAX_RETRIES")
+	if !data.MaxRetries.IsNull() {
+		maxRetries = strconv.FormatInt(data.MaxRetries.ValueInt64(), 10)
+	}
+	if maxRetries != "" {
+		n, err := strconv.Atoi(maxRetries)
+		if err != nil || n < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Retry Configuration",
+				fmt.Sprintf("max_retries must be a non-negative integer, got: %q", maxRetries),
+			)
+			return
+		}
+		client.MaxRetries = n
+	}
+
+	maxRetryWait := os.Getenv("PORKBUN_MAX_RETRY_WAIT")
+	if !data.MaxRetryWait.IsNull() {
+		maxRetryWait = data.MaxRetryWait.ValueString()
+	}
+	if maxRetryWait != "" {
+		d, err := time.ParseDuration(maxRetryWait)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retry_wait"),
+				"Invalid Retry Configuration",
+				fmt.Sprintf("max_retry_wait must be a positive duration such as \"30s\", got: %q", maxRetryWait),
+			)
+			return
+		}
+		client.MaxRetryWait = d
+	}
+
+	cacheTTL := os.Getenv("PORKBUN_CACHE_TTL")
+	if !data.CacheTTL.IsNull() {
+		cacheTTL = data.CacheTTL.ValueString()
+	}
+	if cacheTTL != "" {
+		d, err := time.ParseDuration(cacheTTL)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cache_ttl"),
+				"Invalid Cache Configuration",
+				fmt.Sprintf("cache_ttl must be a positive duration such as \"5m\", got: %q", cacheTTL),
+			)
+			return
+		}
+		client.CacheTTL = d
+	}
+
+	shared := &resourceData{client: client}
+
+	if v := os.Getenv("PORKBUN_ADOPT_EXISTING_RECORDS"); v != "" {
+		adopt, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("adopt_existing_records"),
+				"Invalid DNS Record Configuration",
+				fmt.Sprintf("PORKBUN_ADOPT_EXISTING_RECORDS must be a boolean, got: %q", v),
+			)
+			return
+		}
+		shared.adoptExistingRecords = adopt
+	}
+	if !data.AdoptRecords.IsNull() {
+		shared.adoptExistingRecords = data.AdoptRecords.ValueBool()
+	}
+
+	shared.ownershipMarker = os.Getenv("PORKBUN_RECORD_OWNERSHIP_MARKER")
+	if !data.OwnerMarker.IsNull() {
+		shared.ownershipMarker = data.OwnerMarker.ValueString()
+	}
+	shared.ownershipMarker = strings.TrimSpace(shared.ownershipMarker)
+
+	resp.ResourceData = shared
 	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *PorkbunProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -85,6 +361,8 @@ func (p *PorkbunProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewDomainNameserversResource,
 		NewGlueRecordResource,
 		NewDnssecRecordResource,
+		NewUrlForwardResource,
+		NewDomainResource,
 	}
 }
 
@@ -92,8 +370,19 @@ func (p *PorkbunProvider) DataSources(ctx context.Context) []func() datasource.D
 	return []func() datasource.DataSource{
 		NewPingDataSource,
 		NewDnsRecordsDataSource,
+		NewDnsRecordDataSource,
 		NewTldsDataSource,
 		NewDomainsDataSource,
+		NewDomainDataSource,
+		NewUrlForwardsDataSource,
+		NewSslBundleDataSource,
+		NewDomainAvailabilityDataSource,
+	}
+}
+
+func (p *PorkbunProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSslBundleEphemeralResource,
 	}
 }
 
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+const testAccDomain = "example.com"
+
+// testAccSetup starts a fake Porkbun API holding testAccDomain and returns
+// provider factories wired to it through PORKBUN_BASE_URL, so acceptance tests
+// run fully offline.
+func testAccSetup(t *testing.T) (*porkbuntest.Server, map[string]func() (tfprotov6.ProviderServer, error)) {
+	t.Helper()
+
+	server := porkbuntest.NewServer(t)
+	server.AddDomain(porkbuntest.Domain{Name: testAccDomain})
+	t.Setenv("PORKBUN_BASE_URL", server.URL)
+
+	factories := map[string]func() (tfprotov6.ProviderServer, error){
+		"porkbun": providerserver.NewProtocol6WithError(New("test")()),
+	}
+	return server, factories
+}
+
+// testAccProviderConfig returns the provider block authenticating against the
+// fake API.
+func testAccProviderConfig() string {
+	return fmt.Sprintf(`
+provider "porkbun" {
+  api_key        = %q
+  secret_api_key = %q
+  max_retry_wait = "10ms"
+}
+`, porkbuntest.DefaultAPIKey, porkbuntest.DefaultSecretKey)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &sslBundleDataSource{}
+	_ datasource.DataSourceWithConfigure = &sslBundleDataSource{}
+)
+
+func NewSslBundleDataSource() datasource.DataSource {
+	return &sslBundleDataSource{}
+}
+
+type sslBundleDataSource struct {
+	client *porkbun.Client
+}
+
+type sslBundleDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Domain           types.String `tfsdk:"domain"`
+	CertificateChain types.String `tfsdk:"certificate_chain"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	DNSNames         types.List   `tfsdk:"dns_names"`
+}
+
+func (d *sslBundleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_bundle"
+}
+
+func (d *sslBundleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ruft das kostenlose SSL-Zertifikat ab, das Porkbun für eine Domain ausgestellt hat. Der private Schlüssel ist nur über die gleichnamige Ephemeral Resource verfügbar, damit er nicht im State landet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Wird auf den Domainnamen gesetzt.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der Domainname, dessen Zertifikat abgerufen werden soll.",
+				Required:    true,
+			},
+			"certificate_chain": schema.StringAttribute{
+				Description: "Die Zertifikatskette im PEM-Format, beginnend mit dem Zertifikat der Domain.",
+				Computed:    true,
+			},
+			"public_key": schema.StringAttribute{
+				Description: "Der öffentliche Schlüssel im PEM-Format.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Das Ablaufdatum des Zertifikats im RFC3339-Format.",
+				Computed:    true,
+			},
+			"dns_names": schema.ListAttribute{
+				Description: "Die Domainnamen (SANs), für die das Zertifikat gilt.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *sslBundleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Data Source", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *sslBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config sslBundleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	bundle, cert := retrieveSslBundle(ctx, d.client, domain, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := config
+	state.ID = types.StringValue(domain)
+	state.CertificateChain = types.StringValue(bundle.CertificateChain)
+	state.PublicKey = types.StringValue(bundle.PublicKey)
+	state.ExpiresAt = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+
+	var diags diag.Diagnostics
+	state.DNSNames, diags = types.ListValueFrom(ctx, types.StringType, cert.DNSNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// retrieveSslBundle lädt das Zertifikat einer Domain und parst das erste
+// Zertifikat der Kette. Fehler werden in diags gemeldet.
+func retrieveSslBundle(ctx context.Context, client *porkbun.Client, domain string, diags *diag.Diagnostics) (*porkbun.SslBundle, *x509.Certificate) {
+	bundle, err := client.RetrieveSslBundle(ctx, domain)
+	if err != nil {
+		diags.AddError("Fehler beim Abrufen des SSL-Zertifikats", fmt.Sprintf("Konnte das Zertifikat für %s nicht abrufen: %s", domain, err.Error()))
+		return nil, nil
+	}
+
+	cert, err := parseLeafCertificate(bundle.CertificateChain)
+	if err != nil {
+		diags.AddError("Ungültiges SSL-Zertifikat", fmt.Sprintf("Die Zertifikatskette für %s konnte nicht gelesen werden: %s", domain, err.Error()))
+		return nil, nil
+	}
+	return bundle, cert
+}
+
+func parseLeafCertificate(chain string) (*x509.Certificate, error) {
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("kein PEM-Zertifikat gefunden")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
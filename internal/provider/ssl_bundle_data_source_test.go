@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+var testAccSslExpiry = time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testAccSetSslBundle(t *testing.T, server *porkbuntest.Server) porkbuntest.SslBundle {
+	t.Helper()
+	bundle, err := porkbuntest.NewSslBundle([]string{testAccDomain, "*." + testAccDomain}, testAccSslExpiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.Ssl = &bundle
+	})
+	return bundle
+}
+
+func TestAccSslBundleDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	bundle := testAccSetSslBundle(t, server)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_ssl_bundle" "test" {
+  domain = %q
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "id", testAccDomain),
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "certificate_chain", bundle.CertificateChain),
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "public_key", bundle.PublicKey),
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "expires_at", "2030-06-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "dns_names.#", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_ssl_bundle.test", "dns_names.1", "*."+testAccDomain),
+					resource.TestCheckNoResourceAttr("data.porkbun_ssl_bundle.test", "private_key"),
+					func(s *terraform.State) error {
+						for _, rs := range s.RootModule().Resources {
+							for key, value := range rs.Primary.Attributes {
+								if strings.Contains(value, "PRIVATE KEY") {
+									return fmt.Errorf("private key persisted in state attribute %s", key)
+								}
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccSslBundleDataSource_notReady(t *testing.T) {
+	_, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_ssl_bundle" "test" {
+  domain = %q
+}
+`, testAccDomain),
+				ExpectError: regexp.MustCompile(`Fehler beim Abrufen des SSL-Zertifikats`),
+			},
+		},
+	})
+}
+
+func TestAccSslBundleEphemeralResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	bundle := testAccSetSslBundle(t, server)
+	factories["echo"] = echoprovider.NewProviderServer()
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+ephemeral "porkbun_ssl_bundle" "test" {
+  domain = %q
+}
+
+provider "echo" {
+  data = ephemeral.porkbun_ssl_bundle.test
+}
+
+resource "echo" "test" {}
+`, testAccDomain),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("private_key"), knownvalue.StringExact(bundle.PrivateKey)),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_at"), knownvalue.StringExact("2030-06-01T12:00:00Z")),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("dns_names"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact(testAccDomain),
+						knownvalue.StringExact("*." + testAccDomain),
+					})),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &sslBundleEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &sslBundleEphemeralResource{}
+)
+
+func NewSslBundleEphemeralResource() ephemeral.EphemeralResource {
+	return &sslBundleEphemeralResource{}
+}
+
+type sslBundleEphemeralResource struct {
+	client *porkbun.Client
+}
+
+type sslBundleEphemeralResourceModel struct {
+	Domain           types.String `tfsdk:"domain"`
+	CertificateChain types.String `tfsdk:"certificate_chain"`
+	PrivateKey       types.String `tfsdk:"private_key"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	DNSNames         types.List   `tfsdk:"dns_names"`
+}
+
+func (r *sslBundleEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_bundle"
+}
+
+func (r *sslBundleEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ruft das kostenlose SSL-Zertifikat einer Domain samt privatem Schlüssel ab, ohne es im State zu speichern. Benötigt Terraform 1.10 oder neuer.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Description: "Der Domainname, dessen Zertifikat abgerufen werden soll.",
+				Required:    true,
+			},
+			"certificate_chain": schema.StringAttribute{
+				Description: "Die Zertifikatskette im PEM-Format, beginnend mit dem Zertifikat der Domain.",
+				Computed:    true,
+			},
+			"private_key": schema.StringAttribute{
+				Description: "Der private Schlüssel im PEM-Format.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"public_key": schema.StringAttribute{
+				Description: "Der öffentliche Schlüssel im PEM-Format.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Das Ablaufdatum des Zertifikats im RFC3339-Format.",
+				Computed:    true,
+			},
+			"dns_names": schema.ListAttribute{
+				Description: "Die Domainnamen (SANs), für die das Zertifikat gilt.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *sslBundleEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Ephemeral Resource", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *sslBundleEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config sslBundleEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bundle, cert := retrieveSslBundle(ctx, r.client, config.Domain.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := config
+	result.CertificateChain = types.StringValue(bundle.CertificateChain)
+	result.PrivateKey = types.StringValue(bundle.PrivateKey)
+	result.PublicKey = types.StringValue(bundle.PublicKey)
+	result.ExpiresAt = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+
+	var diags diag.Diagnostics
+	result.DNSNames, diags = types.ListValueFrom(ctx, types.StringType, cert.DNSNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
@@ -78,7 +78,7 @@ func (d *tldsDataSource) Configure(ctx context.Context, req datasource.Configure
 }
 
 func (d *tldsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	pricing, err := d.client.GetPricing()
+	pricing, err := d.client.GetPricing(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Fehler beim Abrufen der TLD-Preise", err.Error())
 		return
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTldsDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.SetPricing(map[string]porkbun.TldPricing{
+		"com": {Registration: "11.08", Renewal: "11.08", Transfer: "11.08"},
+		"dev": {Registration: "12.00", Renewal: "13.50", Transfer: "12.00"},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `data "porkbun_tlds" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_tlds.test", "tlds.%", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_tlds.test", "tlds.dev.registration_price", "12.00"),
+					resource.TestCheckResourceAttr("data.porkbun_tlds.test", "tlds.dev.renewal_price", "13.50"),
+					resource.TestCheckResourceAttr("data.porkbun_tlds.test", "tlds.com.transfer_price", "11.08"),
+				),
+			},
+		},
+	})
+}
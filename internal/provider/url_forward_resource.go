@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &urlForwardResource{}
+var _ resource.ResourceWithConfigure = &urlForwardResource{}
+var _ resource.ResourceWithImportState = &urlForwardResource{}
+var _ resource.ResourceWithValidateConfig = &urlForwardResource{}
+
+func NewUrlForwardResource() resource.Resource {
+	return &urlForwardResource{}
+}
+
+type urlForwardResource struct {
+	client *porkbun.Client
+}
+
+type urlForwardResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Domain      types.String `tfsdk:"domain"`
+	Subdomain   types.String `tfsdk:"subdomain"`
+	Location    types.String `tfsdk:"location"`
+	Type        types.String `tfsdk:"type"`
+	IncludePath types.Bool   `tfsdk:"include_path"`
+	Wildcard    types.Bool   `tfsdk:"wildcard"`
+}
+
+func (r *urlForwardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_url_forward"
+}
+
+func (r *urlForwardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Porkbun kann Weiterleitungen nicht bearbeiten, daher erzwingt jede Änderung eine Neuanlage.
+	resp.Schema = schema.Schema{
+		Description: "Verwaltet eine URL-Weiterleitung bei Porkbun, z. B. für geparkte Domains, die nur auf eine andere Seite verweisen.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Die von Porkbun vergebene ID der Weiterleitung.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Die Domain, für die die Weiterleitung eingerichtet wird (z.B. 'example.com').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subdomain": schema.StringAttribute{
+				Description: "Die Subdomain, die weitergeleitet wird. Leer für die Domain selbst.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "Die Ziel-URL der Weiterleitung (z.B. 'https://example.net').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Die Art der Weiterleitung: 'temporary' (HTTP 302) oder 'permanent' (HTTP 301). Standard ist 'temporary'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("temporary"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_path": schema.BoolAttribute{
+				Description: "Ob der Pfad der aufgerufenen URL an die Ziel-URL angehängt wird.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wildcard": schema.BoolAttribute{
+				Description: "Ob auch alle Subdomains der Subdomain weitergeleitet werden.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *urlForwardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp", fmt.Sprintf("Erwartet *provider.resourceData, erhalten: %T", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *urlForwardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config urlForwardResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if t := config.Type; !t.IsNull() && !t.IsUnknown() && t.ValueString() != "temporary" && t.ValueString() != "permanent" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Ungültige Weiterleitungsart",
+			fmt.Sprintf("Erlaubt sind 'temporary' und 'permanent', erhalten: %q.", t.ValueString()),
+		)
+	}
+}
+
+func (r *urlForwardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan urlForwardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forward := porkbun.UrlForward{
+		Subdomain:   plan.Subdomain.ValueString(),
+		Location:    plan.Location.ValueString(),
+		Type:        plan.Type.ValueString(),
+		IncludePath: porkbun.FlexBool(plan.IncludePath.ValueBool()),
+		Wildcard:    porkbun.FlexBool(plan.Wildcard.ValueBool()),
+	}
+
+	id, err := r.client.AddUrlForward(ctx, plan.Domain.ValueString(), forward)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Erstellen der URL-Weiterleitung", "Konnte URL-Weiterleitung nicht hinzufügen: "+err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *urlForwardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state urlForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forwards, err := r.client.GetUrlForwarding(ctx, state.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Lesen der URL-Weiterleitungen", "Konnte URL-Weiterleitungen für die Domain nicht abrufen: "+err.Error())
+		return
+	}
+
+	var found *porkbun.UrlForward
+	for i := range forwards {
+		if forwards[i].ID == state.ID.ValueString() {
+			found = &forwards[i]
+			break
+		}
+	}
+	if found == nil {
+		tflog.Warn(ctx, "URL-Weiterleitung nicht gefunden, wird aus dem State entfernt.", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Porkbun speichert Subdomains klein geschrieben. Die Schreibweise aus der
+	// Konfiguration bleibt erhalten, sonst würde jeder Plan die Weiterleitung
+	// ersetzen.
+	if state.Subdomain.IsNull() || !strings.EqualFold(state.Subdomain.ValueString(), found.Subdomain) {
+		state.Subdomain = types.StringValue(found.Subdomain)
+	}
+	state.Location = types.StringValue(found.Location)
+	state.Type = types.StringValue(strings.ToLower(found.Type))
+	state.IncludePath = types.BoolValue(bool(found.IncludePath))
+	state.Wildcard = types.BoolValue(bool(found.Wildcard))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update wird nie aufgerufen, da jede Änderung eine Neuanlage erzwingt.
+func (r *urlForwardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Aktualisierung nicht unterstützt", "URL-Weiterleitungen können nicht bearbeitet, nur neu angelegt werden.")
+}
+
+func (r *urlForwardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state urlForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteUrlForward(ctx, state.Domain.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		resp.Diagnostics.AddError("Fehler beim Löschen der URL-Weiterleitung", err.Error())
+		return
+	}
+}
+
+func (r *urlForwardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unerwarteter Import-Bezeichner",
+			fmt.Sprintf("Erwarteter Bezeichner im Format 'domain/id'. Erhalten: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbuntest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccUrlForwardResource(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		CheckDestroy: func(*terraform.State) error {
+			d, _ := server.Domain(testAccDomain)
+			if len(d.UrlForwards) != 0 {
+				return fmt.Errorf("expected URL forwards to be destroyed, found %v", d.UrlForwards)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUrlForwardConfig("https://example.org", `  type = "moved"`),
+				ExpectError: regexp.MustCompile(`Ungültige Weiterleitungsart`),
+			},
+			{
+				Config: testAccUrlForwardConfig("https://example.net", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("porkbun_url_forward.test", "id"),
+					resource.TestCheckResourceAttr("porkbun_url_forward.test", "subdomain", ""),
+					resource.TestCheckResourceAttr("porkbun_url_forward.test", "type", "temporary"),
+					resource.TestCheckResourceAttr("porkbun_url_forward.test", "include_path", "false"),
+					resource.TestCheckResourceAttr("porkbun_url_forward.test", "wildcard", "false"),
+					func(*terraform.State) error {
+						d, _ := server.Domain(testAccDomain)
+						if len(d.UrlForwards) != 1 || d.UrlForwards[0].Location != "https://example.net" {
+							return fmt.Errorf("unexpected URL forwards %v", d.UrlForwards)
+						}
+						return nil
+					},
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      "porkbun_url_forward.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportID("porkbun_url_forward.test", "domain", "id"),
+			},
+			{
+				Config: testAccUrlForwardConfig("https://example.org", `
+  type         = "permanent"
+  include_path = true
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_url_forward.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: func(*terraform.State) error {
+					d, _ := server.Domain(testAccDomain)
+					if len(d.UrlForwards) != 1 || d.UrlForwards[0].Type != "permanent" || d.UrlForwards[0].IncludePath != "yes" {
+						return fmt.Errorf("unexpected URL forwards %v", d.UrlForwards)
+					}
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+						d.UrlForwards = nil
+					})
+				},
+				Config: testAccUrlForwardConfig("https://example.org", `
+  type         = "permanent"
+  include_path = true
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("porkbun_url_forward.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccUrlForwardResource_mixedCaseSubdomain(t *testing.T) {
+	server, factories := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUrlForwardConfig("https://example.net", `  subdomain = "Blog"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("porkbun_url_forward.test", "subdomain", "Blog"),
+					func(*terraform.State) error {
+						d, _ := server.Domain(testAccDomain)
+						if len(d.UrlForwards) != 1 || d.UrlForwards[0].Subdomain != "blog" {
+							return fmt.Errorf("unexpected URL forwards %v", d.UrlForwards)
+						}
+						return nil
+					},
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccUrlForwardsDataSource(t *testing.T) {
+	server, factories := testAccSetup(t)
+	server.UpdateDomain(testAccDomain, func(d *porkbuntest.Domain) {
+		d.UrlForwards = []porkbuntest.UrlForward{
+			{ID: "1", Subdomain: "", Location: "https://example.net", Type: "permanent", IncludePath: "yes", Wildcard: "no"},
+			{ID: "2", Subdomain: "shop", Location: "https://shop.example.net", Type: "temporary", IncludePath: "no", Wildcard: "yes"},
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "porkbun_url_forwards" "test" {
+  domain = %q
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "id", testAccDomain),
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "forwards.#", "2"),
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "forwards.0.type", "permanent"),
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "forwards.0.include_path", "true"),
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "forwards.1.subdomain", "shop"),
+					resource.TestCheckResourceAttr("data.porkbun_url_forwards.test", "forwards.1.wildcard", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUrlForwardConfig(location, extra string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "porkbun_url_forward" "test" {
+  domain   = %q
+  location = %q
+%s
+}
+`, testAccDomain, location, extra)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flooopro/terraform-provider-porkbun/internal/porkbun"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &urlForwardsDataSource{}
+	_ datasource.DataSourceWithConfigure = &urlForwardsDataSource{}
+)
+
+func NewUrlForwardsDataSource() datasource.DataSource {
+	return &urlForwardsDataSource{}
+}
+
+type urlForwardsDataSource struct {
+	client *porkbun.Client
+}
+
+type urlForwardsDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Domain   types.String `tfsdk:"domain"`
+	Forwards types.List   `tfsdk:"forwards"`
+}
+
+func urlForwardAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":           types.StringType,
+		"subdomain":    types.StringType,
+		"location":     types.StringType,
+		"type":         types.StringType,
+		"include_path": types.BoolType,
+		"wildcard":     types.BoolType,
+	}
+}
+
+func (d *urlForwardsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_url_forwards"
+}
+
+func (d *urlForwardsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ruft alle URL-Weiterleitungen einer Domain ab.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Wird auf den Domainnamen gesetzt.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Der Domainname, dessen Weiterleitungen abgerufen werden sollen.",
+				Required:    true,
+			},
+			"forwards": schema.ListNestedAttribute{
+				Description: "Die Liste der URL-Weiterleitungen der Domain.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":           schema.StringAttribute{Computed: true},
+						"subdomain":    schema.StringAttribute{Computed: true},
+						"location":     schema.StringAttribute{Computed: true},
+						"type":         schema.StringAttribute{Computed: true},
+						"include_path": schema.BoolAttribute{Computed: true},
+						"wildcard":     schema.BoolAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *urlForwardsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*porkbun.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unerwarteter Konfigurationstyp für Data Source", fmt.Sprintf("Erwartet wurde *porkbun.Client, erhalten: %T.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *urlForwardsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config urlForwardsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	forwards, err := d.client.GetUrlForwarding(ctx, domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Fehler beim Abrufen der URL-Weiterleitungen", fmt.Sprintf("Konnte Weiterleitungen für Domain %s nicht abrufen: %s", domain, err.Error()))
+		return
+	}
+
+	var state urlForwardsDataSourceModel
+	state.Domain = types.StringValue(domain)
+	state.ID = types.StringValue(domain)
+
+	forwardModels := make([]attr.Value, 0, len(forwards))
+	for _, forward := range forwards {
+		forwardModels = append(forwardModels, types.ObjectValueMust(
+			urlForwardAttributeTypes(),
+			map[string]attr.Value{
+				"id":           types.StringValue(forward.ID),
+				"subdomain":    types.StringValue(forward.Subdomain),
+				"location":     types.StringValue(forward.Location),
+				"type":         types.StringValue(strings.ToLower(forward.Type)),
+				"include_path": types.BoolValue(bool(forward.IncludePath)),
+				"wildcard":     types.BoolValue(bool(forward.Wildcard)),
+			},
+		))
+	}
+	state.Forwards = types.ListValueMust(types.ObjectType{AttrTypes: urlForwardAttributeTypes()}, forwardModels)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}